@@ -29,19 +29,27 @@ type Client interface {
 	TransactionReceipt(context.Context, ethcommon.Hash) (*ethtypes.Receipt, error)
 	TraceTransaction(context.Context, string) (*Call, []*FlatCall, error)
 	TraceBlockByHash(context.Context, string) ([]*Call, [][]*FlatCall, error)
+	TraceCall(context.Context, interfaces.CallMsg) (*Call, []*FlatCall, error)
+	CreateAccessList(context.Context, interfaces.CallMsg) (*ethtypes.AccessList, uint64, error)
 	SendTransaction(context.Context, *ethtypes.Transaction) error
 	BalanceAt(context.Context, ethcommon.Address, *big.Int) (*big.Int, error)
 	NonceAt(context.Context, ethcommon.Address, *big.Int) (uint64, error)
+	PendingNonceAt(context.Context, ethcommon.Address) (uint64, error)
 	SuggestGasPrice(context.Context) (*big.Int, error)
+	SuggestGasTipCap(context.Context) (*big.Int, error)
 	EstimateGas(context.Context, interfaces.CallMsg) (uint64, error)
 	TxPoolContent(context.Context) (*TxPoolContent, error)
 	GetNetworkName(context.Context, ...rpc.Option) (string, error)
 	Peers(context.Context, ...rpc.Option) ([]info.Peer, error)
 	GetContractInfo(ethcommon.Address, bool) (string, uint8, error)
 	CallContract(context.Context, interfaces.CallMsg, *big.Int) ([]byte, error)
+	CodeAt(context.Context, ethcommon.Address, *big.Int) ([]byte, error)
+	StorageAt(context.Context, ethcommon.Address, ethcommon.Hash, *big.Int) ([]byte, error)
+	FilterLogs(context.Context, interfaces.FilterQuery) ([]ethtypes.Log, error)
 	GetNetworkID(context.Context, ...rpc.Option) (uint32, error)
 	GetBlockchainID(context.Context, string, ...rpc.Option) (ids.ID, error)
 	IssueTx(ctx context.Context, txBytes []byte) (ids.ID, error)
+	GetAtomicTxStatus(ctx context.Context, txID ids.ID) (evm.Status, error)
 	GetAtomicUTXOs(ctx context.Context, addrs []string, sourceChain string, limit uint32, startAddress, startUTXOID string) ([][]byte, api.Index, error)
 	EstimateBaseFee(ctx context.Context) (*big.Int, error)
 }
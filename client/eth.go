@@ -2,11 +2,16 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	ethtypes "github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/eth/tracers"
 	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/interfaces"
 	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 var (
@@ -50,6 +55,16 @@ func (c *EthClient) TxPoolContent(ctx context.Context) (*TxPoolContent, error) {
 	return &content, err
 }
 
+// PendingNonceAt returns the account nonce of the given account in the pending
+// state, counting the transactions waiting in the tx pool
+func (c *EthClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var result hexutil.Uint64
+
+	err := c.rpc.CallContext(ctx, &result, "eth_getTransactionCount", account, "pending")
+
+	return uint64(result), err
+}
+
 // TraceTransaction returns a transaction trace
 func (c *EthClient) TraceTransaction(ctx context.Context, hash string) (*Call, []*FlatCall, error) {
 	var result Call
@@ -84,3 +99,73 @@ func (c *EthClient) TraceBlockByHash(ctx context.Context, hash string) ([]*Call,
 
 	return result, flattened, nil
 }
+
+// TraceCall returns the trace of a call executed on top of the latest block,
+// along with the logs it emits if the node's call tracer supports withLog
+func (c *EthClient) TraceCall(ctx context.Context, msg interfaces.CallMsg) (*Call, []*FlatCall, error) {
+	var result Call
+
+	config := map[string]interface{}{
+		"tracer":       tracer,
+		"timeout":      tracerTimeout,
+		"tracerConfig": map[string]interface{}{"withLog": true},
+	}
+	err := c.rpc.CallContext(ctx, &result, "debug_traceCall", toCallArg(msg), "latest", config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flattened := result.init()
+
+	return &result, flattened, nil
+}
+
+// CreateAccessList returns the access list of a call executed on top of the
+// latest block, and the gas it uses with that access list
+func (c *EthClient) CreateAccessList(ctx context.Context, msg interfaces.CallMsg) (*ethtypes.AccessList, uint64, error) {
+	var result struct {
+		AccessList *ethtypes.AccessList `json:"accessList"`
+		GasUsed    hexutil.Uint64       `json:"gasUsed"`
+		Error      string               `json:"error,omitempty"`
+	}
+
+	err := c.rpc.CallContext(ctx, &result, "eth_createAccessList", toCallArg(msg), "latest")
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(result.Error) > 0 {
+		return nil, 0, errors.New(result.Error)
+	}
+
+	return result.AccessList, uint64(result.GasUsed), nil
+}
+
+// toCallArg encodes msg the way eth_call and debug_traceCall expect it
+func toCallArg(msg interfaces.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	if msg.AccessList != nil {
+		arg["accessList"] = msg.AccessList
+	}
+	return arg
+}
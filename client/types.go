@@ -3,6 +3,7 @@ package client
 import (
 	"math/big"
 
+	ethtypes "github.com/ava-labs/coreth/core/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -50,6 +51,15 @@ type Call struct {
 	Revert  bool           `json:"revert"`
 	Error   string         `json:"error,omitempty"`
 	Calls   []*Call        `json:"calls,omitempty"`
+	Logs    []*CallLog     `json:"logs,omitempty"`
+}
+
+// CallLog is a log emitted by a call, only reported by the call tracer when
+// its withLog option is set
+type CallLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
 }
 
 type FlatCall struct {
@@ -107,3 +117,24 @@ func (c *Call) init() []*FlatCall {
 
 	return results
 }
+
+// EmittedLogs returns the logs emitted by the call and its children, leaving
+// out the ones of reverted calls
+func (c *Call) EmittedLogs() []*ethtypes.Log {
+	if c.Revert || len(c.Error) > 0 {
+		return nil
+	}
+
+	logs := []*ethtypes.Log{}
+	for _, log := range c.Logs {
+		logs = append(logs, &ethtypes.Log{
+			Address: log.Address,
+			Topics:  log.Topics,
+			Data:    log.Data,
+		})
+	}
+	for _, child := range c.Calls {
+		logs = append(logs, child.EmittedLogs()...)
+	}
+	return logs
+}
@@ -3,9 +3,15 @@ package main
 import (
 	"encoding/json"
 	"errors"
+	"math/big"
 	"os"
 
+	"github.com/ava-labs/avalanchego/ids"
+
 	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/logger"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
 	"github.com/ava-labs/avalanche-rosetta/service"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 )
@@ -18,6 +24,9 @@ var (
 	errInvalidErc20Address     = errors.New("not all token addresses provided are valid erc20s")
 	errInvalidIngestionMode    = errors.New("invalid rosetta ingestion mode")
 	errInvalidUnknownTokenMode = errors.New("cannot index unknown tokens while in standard ingestion mode")
+	errInvalidBlockchainID     = errors.New("invalid blockchain id provided")
+	errInvalidMaxFee           = errors.New("invalid max fee provided")
+	errMissingDataDir          = errors.New("data dir is required to index account history")
 )
 
 type config struct {
@@ -27,12 +36,33 @@ type config struct {
 	NetworkName      string `json:"network_name"`
 	ChainID          int64  `json:"chain_id"`
 	LogRequests      bool   `json:"log_requests"`
+	LogLevel         string `json:"log_level"`
 	GenesisBlockHash string `json:"genesis_block_hash"`
+	DataDir          string `json:"data_dir"`
+
+	IndexAccountHistory   bool  `json:"index_account_history"`
+	IndexStartBlockHeight int64 `json:"index_start_block_height"`
+
+	RebroadcastDroppedTxs bool `json:"rebroadcast_dropped_txs"`
+
+	// BlockchainIDs overrides the built-in blockchain ids of chain aliases
+	BlockchainIDs map[string]string `json:"blockchain_ids"`
 
 	IngestionMode          string   `json:"ingestion_mode"`
 	TokenWhiteList         []string `json:"token_whitelist"`
 	IndexUnknownTokens     bool     `json:"index_unknown_tokens"`
 	ValidateERC20Whitelist bool     `json:"validate_erc20_whitelist"`
+
+	// ReplacementFeeBumpPercent is the minimum gas price increase of a
+	// transaction replacing a pending one
+	ReplacementFeeBumpPercent uint64 `json:"replacement_fee_bump_percent"`
+
+	// GasLimitBufferPercent is added to estimated gas limits
+	GasLimitBufferPercent uint64 `json:"gas_limit_buffer_percent"`
+
+	// MaxFees caps the fee, in wei, of the transactions transferring AVAX or
+	// the token of a contract address
+	MaxFees map[string]string `json:"max_fees"`
 }
 
 func readConfig(path string) (*config, error) {
@@ -64,6 +94,18 @@ func (c *config) ApplyDefaults() {
 	if c.ListenAddr == "" {
 		c.ListenAddr = "0.0.0.0:8080"
 	}
+
+	if c.ReplacementFeeBumpPercent == 0 {
+		c.ReplacementFeeBumpPercent = service.DefaultReplacementFeeBumpPercent
+	}
+
+	// Request bodies are logged at debug level
+	if c.LogLevel == "" && c.LogRequests {
+		c.LogLevel = logger.LevelDebug
+	}
+	if c.LogLevel == "" {
+		c.LogLevel = logger.LevelInfo
+	}
 }
 
 func (c *config) Validate() error {
@@ -81,6 +123,10 @@ func (c *config) Validate() error {
 		return errGenesisBlockRequired
 	}
 
+	if _, err := logger.ParseLevel(c.LogLevel); err != nil {
+		return err
+	}
+
 	if len(c.TokenWhiteList) != 0 {
 		for _, token := range c.TokenWhiteList {
 			if !ethcommon.IsHexAddress(token) {
@@ -89,6 +135,16 @@ func (c *config) Validate() error {
 		}
 	}
 
+	if _, err := c.ServiceMaxFees(); err != nil {
+		return err
+	}
+
+	for _, blockchainID := range c.BlockchainIDs {
+		if _, err := ids.FromString(blockchainID); err != nil {
+			return errInvalidBlockchainID
+		}
+	}
+
 	if !(c.IngestionMode == service.AnalyticsIngestion || c.IngestionMode == service.StandardIngestion) {
 		return errInvalidIngestionMode
 	}
@@ -96,6 +152,10 @@ func (c *config) Validate() error {
 	if c.IngestionMode == service.StandardIngestion && c.IndexUnknownTokens {
 		return errInvalidUnknownTokenMode
 	}
+
+	if c.IndexAccountHistory && c.DataDir == "" {
+		return errMissingDataDir
+	}
 	return nil
 }
 
@@ -112,3 +172,47 @@ func (c *config) ValidateWhitelistOnlyValidErc20s(cli client.Client) error {
 	}
 	return nil
 }
+
+// ServiceMaxFees returns the configured max fees keyed the way the service
+// looks them up
+func (c *config) ServiceMaxFees() (map[string]*big.Int, error) {
+	maxFees := map[string]*big.Int{}
+	for key, value := range c.MaxFees {
+		switch {
+		case key == mapper.AvaxCurrency.Symbol:
+		case ethcommon.IsHexAddress(key):
+			key = ethcommon.HexToAddress(key).Hex()
+		default:
+			return nil, errInvalidMaxFee
+		}
+
+		maxFee, ok := new(big.Int).SetString(value, 10)
+		if !ok || maxFee.Sign() <= 0 {
+			return nil, errInvalidMaxFee
+		}
+		maxFees[key] = maxFee
+	}
+	return maxFees, nil
+}
+
+// NetworkConstants returns the P-chain network constants of the configured
+// chain with the configured blockchain ids applied over the built-in ones
+func (c *config) NetworkConstants() *pmapper.NetworkConstants {
+	builtin := pmapper.GetNetworkConstants(c.ChainID)
+	if builtin == nil {
+		return nil
+	}
+
+	constants := &pmapper.NetworkConstants{
+		NetworkID:     builtin.NetworkID,
+		BlockchainIDs: map[string]ids.ID{},
+	}
+	for chain, blockchainID := range builtin.BlockchainIDs {
+		constants.BlockchainIDs[chain] = blockchainID
+	}
+	for chain, blockchainID := range c.BlockchainIDs {
+		// Validate ensures the configured ids parse
+		constants.BlockchainIDs[chain], _ = ids.FromString(blockchainID)
+	}
+	return constants
+}
@@ -1,26 +1,35 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"flag"
-	"io/ioutil"
 	"log"
 	"math/big"
 	"net/http"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/coinbase/rosetta-sdk-go/asserter"
 	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/logger"
 	"github.com/ava-labs/avalanche-rosetta/mapper"
 	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
 	"github.com/ava-labs/avalanche-rosetta/service"
 	c "github.com/ava-labs/avalanche-rosetta/service/backend/cchainatomictx"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/gasprice"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/nonce"
 	p "github.com/ava-labs/avalanche-rosetta/service/backend/pchain"
 	pIndexer "github.com/ava-labs/avalanche-rosetta/service/backend/pchain/indexer"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/search"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/submission"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/tracker"
 )
 
 var (
@@ -57,9 +66,18 @@ func main() {
 		log.Fatal("config validation error:", err)
 	}
 
+	zapLogger, err := logger.New(cfg.LogLevel)
+	if err != nil {
+		log.Fatal("logger init error:", err)
+	}
+	defer zapLogger.Sync() //nolint:errcheck
+	zap.ReplaceGlobals(zapLogger)
+	// Messages of the standard logger are written as info entries
+	zap.RedirectStdLog(zapLogger)
+
 	apiClient, err := client.NewClient(context.Background(), cfg.RPCEndpoint)
 	if err != nil {
-		log.Fatal("client init error:", err)
+		zapLogger.Fatal("client init error", zap.Error(err))
 	}
 
 	// [ValidateERC20Whitelist] is disabled by default because it requires
@@ -69,22 +87,22 @@ func main() {
 	// TODO: Only perform this check after the underlying node is bootstrapped
 	if cfg.Mode == service.ModeOnline && cfg.ValidateERC20Whitelist {
 		if err := cfg.ValidateWhitelistOnlyValidErc20s(apiClient); err != nil {
-			log.Fatal("token whitelist validation error:", err)
+			zapLogger.Fatal("token whitelist validation error", zap.Error(err))
 		}
 	}
 
-	log.Println("starting server in", cfg.Mode, "mode")
+	zapLogger.Info("starting server", zap.String("mode", cfg.Mode))
 
 	if cfg.ChainID == 0 {
-		log.Println("chain id is not provided, fetching from rpc...")
+		zapLogger.Info("chain id is not provided, fetching from rpc")
 
 		if cfg.Mode == service.ModeOffline {
-			log.Fatal("cant fetch chain id in offline mode")
+			zapLogger.Fatal("cant fetch chain id in offline mode")
 		}
 
 		chainID, err := apiClient.ChainID(context.Background())
 		if err != nil {
-			log.Fatal("cant fetch chain id from rpc:", err)
+			zapLogger.Fatal("cant fetch chain id from rpc", zap.Error(err))
 		}
 		cfg.ChainID = chainID.Int64()
 	}
@@ -99,19 +117,19 @@ func main() {
 		assetID = mapper.FujiAssetID
 		AP5Activation = mapper.FujiAP5Activation.Uint64()
 	default:
-		log.Fatal("invalid ChainID:", cfg.ChainID)
+		zapLogger.Fatal("invalid chain id", zap.Int64("chain_id", cfg.ChainID))
 	}
 
 	if cfg.NetworkName == "" {
-		log.Println("network name is not provided, fetching from rpc...")
+		zapLogger.Info("network name is not provided, fetching from rpc")
 
 		if cfg.Mode == service.ModeOffline {
-			log.Fatal("cant fetch network name in offline mode")
+			zapLogger.Fatal("cant fetch network name in offline mode")
 		}
 
 		networkName, err := apiClient.GetNetworkName(context.Background())
 		if err != nil {
-			log.Fatal("cant fetch network name:", err)
+			zapLogger.Fatal("cant fetch network name", zap.Error(err))
 		}
 		cfg.NetworkName = networkName
 	}
@@ -132,6 +150,9 @@ func main() {
 	operationTypes = append(operationTypes, mapper.OperationTypes...)
 	operationTypes = append(operationTypes, pmapper.OperationTypes...)
 
+	// Methods answered on both chains are part of both lists
+	callMethods := service.CallMethods(c.CallMethods, submission.CallMethods, pmapper.CallMethods)
+
 	asserter, err := asserter.NewServer(
 		operationTypes, // supported operation types
 		true,           // historical balance lookup
@@ -139,13 +160,16 @@ func main() {
 			networkP,
 			networkC,
 		},
-		[]string{}, // call methods
-		false,      // mempool coins
+		callMethods, // call methods
+		false,       // mempool coins
 	)
 	if err != nil {
-		log.Fatal("server asserter init error:", err)
+		zapLogger.Fatal("server asserter init error", zap.Error(err))
 	}
 
+	// Validate ensures the max fees parse
+	maxFees, _ := cfg.ServiceMaxFees()
+
 	serviceConfig := &service.Config{
 		Mode:               cfg.Mode,
 		ChainID:            big.NewInt(cfg.ChainID),
@@ -156,41 +180,118 @@ func main() {
 		IndexUnknownTokens: cfg.IndexUnknownTokens,
 		IngestionMode:      cfg.IngestionMode,
 		TokenWhiteList:     cfg.TokenWhiteList,
+
+		ReplacementFeeBumpPercent: cfg.ReplacementFeeBumpPercent,
+		GasLimitBufferPercent:     cfg.GasLimitBufferPercent,
+		MaxFees:                   maxFees,
 	}
 
 	avaxAssetID, err := ids.FromString(assetID)
 	if err != nil {
-		log.Fatal("parse asset id failed:", err)
+		zapLogger.Fatal("parse asset id failed", zap.Error(err))
 	}
 
 	pChainClient := client.NewPChainClient(context.Background(), cfg.RPCEndpoint)
 	pChainIndexParser, err := pIndexer.NewParser(pChainClient)
 	if err != nil {
-		log.Fatal("unable to construct p-chain index parser:", err)
+		zapLogger.Fatal("unable to construct p-chain index parser", zap.Error(err))
 	}
 
-	pChainBackend := p.NewBackend(pChainClient, pChainIndexParser, avaxAssetID, networkP)
+	submissionTracker := submission.NewTracker(apiClient, pChainClient, cfg.RebroadcastDroppedTxs)
+
+	pNetworkConstants := cfg.NetworkConstants()
+	pChainBackend := p.NewBackend(
+		pChainClient,
+		pChainIndexParser,
+		avaxAssetID,
+		networkP,
+		pNetworkConstants,
+		cfg.Mode == service.ModeOffline,
+		submissionTracker,
+	)
+
+	cAtomicTxBackend := c.NewBackend(apiClient, avaxAssetID, submissionTracker)
 
-	cAtomicTxBackend := c.NewBackend(apiClient, avaxAssetID)
+	// The block logs and the transaction index grow with every block, so they
+	// are only kept, and /events/blocks and /search/transactions only served,
+	// with a data dir
+	var (
+		cBlockEvents, pBlockEvents service.EventsBackend
+		blockIndexer               service.BlockIndexer
+		searchBackend              service.SearchBackend
+	)
+	if cfg.DataDir != "" {
+		db, err := openDatabase(cfg.DataDir)
+		if err != nil {
+			zapLogger.Fatal("unable to open database", zap.Error(err))
+		}
 
-	handler := configureRouter(serviceConfig, asserter, apiClient, pChainBackend, cAtomicTxBackend)
-	if cfg.LogRequests {
-		handler = inspectMiddleware(handler)
+		cBlockLog, err := tracker.NewBlockLog(prefixdb.New([]byte(mapper.CChainNetworkIdentifier), db))
+		if err != nil {
+			zapLogger.Fatal("unable to load c-chain block log", zap.Error(err))
+		}
+		pBlockLog, err := tracker.NewBlockLog(prefixdb.New([]byte(mapper.PChainNetworkIdentifier), db))
+		if err != nil {
+			zapLogger.Fatal("unable to load p-chain block log", zap.Error(err))
+		}
+		cBlockTracker := tracker.NewTracker(tracker.NewCChain(apiClient), cBlockLog, networkC)
+		pBlockTracker := tracker.NewTracker(tracker.NewPChain(pChainIndexParser), pBlockLog, networkP)
+		if cfg.Mode == service.ModeOnline {
+			go cBlockTracker.Run(context.Background())
+			go pBlockTracker.Run(context.Background())
+		}
+		cBlockEvents, pBlockEvents = cBlockTracker, pBlockTracker
+
+		txIndex := search.NewIndex(prefixdb.New([]byte("search"), db))
+		if cfg.Mode == service.ModeOnline && cfg.IndexAccountHistory {
+			zapLogger.Info("indexing c-chain account history", zap.Int64("start_block", cfg.IndexStartBlockHeight))
+
+			indexer := search.NewIndexer(
+				txIndex,
+				tracker.NewCChain(apiClient),
+				service.NewBlockService(serviceConfig, apiClient, pChainBackend, nil),
+				networkC,
+				cfg.IndexStartBlockHeight,
+			)
+			go indexer.Run(context.Background())
+		}
+		blockIndexer, searchBackend = txIndex, txIndex
 	}
-	handler = server.LoggerMiddleware(handler)
+
+	nonceLedger := nonce.NewLedger(apiClient)
+	gasPriceOracle := gasprice.NewOracle(apiClient)
+	if cfg.Mode == service.ModeOnline {
+		go submissionTracker.Run(context.Background())
+		go nonceLedger.Run(context.Background())
+	}
+
+	handler := configureRouter(
+		serviceConfig,
+		asserter,
+		apiClient,
+		pChainBackend,
+		cAtomicTxBackend,
+		cBlockEvents,
+		pBlockEvents,
+		blockIndexer,
+		searchBackend,
+		submissionTracker,
+		nonceLedger,
+		gasPriceOracle,
+	)
+	handler = logger.Middleware(zap.L(), handler)
 
 	router := server.CorsMiddleware(handler)
 
-	log.Printf(
-		`using avax (chain=%q chainid="%d" network=%q) rpc endpoint: %v`,
-		service.BlockchainName,
-		cfg.ChainID,
-		cfg.NetworkName,
-		cfg.RPCEndpoint,
+	zapLogger.Info("using avax rpc endpoint",
+		zap.String("chain", service.BlockchainName),
+		zap.Int64("chain_id", cfg.ChainID),
+		zap.String("network", cfg.NetworkName),
+		zap.String("rpc_endpoint", cfg.RPCEndpoint),
 	)
-	log.Printf("starting rosetta server at %s\n", cfg.ListenAddr)
+	zapLogger.Info("starting rosetta server", zap.String("listen_addr", cfg.ListenAddr))
 
-	log.Fatal(http.ListenAndServe(cfg.ListenAddr, router))
+	zapLogger.Fatal("server stopped", zap.Error(http.ListenAndServe(cfg.ListenAddr, router)))
 }
 
 func configureRouter(
@@ -199,13 +300,42 @@ func configureRouter(
 	apiClient client.Client,
 	pChainBackend *p.Backend,
 	cAtomicTxBackend *c.Backend,
+	cBlockEvents service.EventsBackend,
+	pBlockEvents service.EventsBackend,
+	blockIndexer service.BlockIndexer,
+	searchBackend service.SearchBackend,
+	submissionTracker *submission.Tracker,
+	nonceLedger *nonce.Ledger,
+	gasPriceOracle *gasprice.Oracle,
 ) http.Handler {
-	networkService := service.NewNetworkService(serviceConfig, apiClient, pChainBackend)
-	blockService := service.NewBlockService(serviceConfig, apiClient, pChainBackend)
+	networkService := service.NewNetworkService(
+		serviceConfig,
+		apiClient,
+		pChainBackend,
+		c.CallMethods,
+		submission.CallMethods,
+	)
+	blockService := service.NewBlockService(serviceConfig, apiClient, pChainBackend, blockIndexer)
 	accountService := service.NewAccountService(serviceConfig, apiClient, pChainBackend, cAtomicTxBackend)
-	mempoolService := service.NewMempoolService(serviceConfig, apiClient)
-	constructionService := service.NewConstructionService(serviceConfig, apiClient, pChainBackend, cAtomicTxBackend)
-	callService := service.NewCallService(serviceConfig, apiClient)
+	mempoolService := service.NewMempoolService(serviceConfig, apiClient, pChainBackend, cAtomicTxBackend)
+	constructionService := service.NewConstructionService(
+		serviceConfig,
+		apiClient,
+		pChainBackend,
+		cAtomicTxBackend,
+		submissionTracker,
+		nonceLedger,
+		gasPriceOracle,
+	)
+	callService := service.NewCallService(
+		serviceConfig,
+		apiClient,
+		pChainBackend,
+		cAtomicTxBackend,
+		submissionTracker,
+	)
+	eventsService := service.NewEventsService(serviceConfig, cBlockEvents, pBlockEvents)
+	searchService := service.NewSearchService(serviceConfig, searchBackend)
 
 	return server.NewRouter(
 		server.NewNetworkAPIController(networkService, asserter),
@@ -214,20 +344,13 @@ func configureRouter(
 		server.NewMempoolAPIController(mempoolService, asserter),
 		server.NewConstructionAPIController(constructionService, asserter),
 		server.NewCallAPIController(callService, asserter),
+		server.NewEventsAPIController(eventsService, asserter),
+		server.NewSearchAPIController(searchService, asserter),
 	)
 }
 
-// Inspect middlware used to inspect the body of requets
-func inspectMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		body, err := ioutil.ReadAll(r.Body)
-		if err != nil {
-			panic(err)
-		}
-		body = bytes.TrimSpace(body)
-		r.Body = ioutil.NopCloser(bytes.NewBuffer(body))
-
-		log.Printf("[DEBUG] %s %s: %s\n", r.Method, r.URL.Path, body)
-		next.ServeHTTP(w, r)
-	})
+// openDatabase opens the leveldb database in dataDir
+func openDatabase(dataDir string) (database.Database, error) {
+	// Metrics are disabled as the server does not expose a metrics registry
+	return leveldb.New(dataDir, []byte(`{"metricUpdateFrequency":0}`), logging.NoLog{}, "", nil)
 }
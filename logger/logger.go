@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+var errInvalidLevel = errors.New("invalid log level")
+
+type contextKey struct{}
+
+// ParseLevel returns the zap level of one of the supported level names
+func ParseLevel(level string) (zapcore.Level, error) {
+	switch level {
+	case LevelDebug:
+		return zapcore.DebugLevel, nil
+	case LevelInfo:
+		return zapcore.InfoLevel, nil
+	case LevelWarn:
+		return zapcore.WarnLevel, nil
+	case LevelError:
+		return zapcore.ErrorLevel, nil
+	default:
+		return zapcore.InfoLevel, fmt.Errorf("%w: %q", errInvalidLevel, level)
+	}
+}
+
+// New returns a logger writing JSON entries of level and above to stderr
+func New(level string) (*zap.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := zap.NewProductionConfig()
+	cfg.Level = zap.NewAtomicLevelAt(lvl)
+	cfg.Sampling = nil
+	cfg.EncoderConfig.TimeKey = "time"
+	cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	return cfg.Build()
+}
+
+// WithContext returns a copy of ctx carrying log
+func WithContext(ctx context.Context, log *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, log)
+}
+
+// FromContext returns the logger of the request ctx belongs to, tagged with
+// its request id, or the global logger outside of requests
+func FromContext(ctx context.Context) *zap.Logger {
+	if log, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return log
+	}
+	return zap.L()
+}
@@ -0,0 +1,124 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// RequestIDHeader carries the correlation id of a request. It is taken
+	// from the request if set, generated otherwise, and echoed in the response.
+	RequestIDHeader = "X-Request-Id"
+
+	redacted = "[redacted]"
+)
+
+// redactedFields are the request fields carrying transactions, signing
+// payloads or signatures, at any depth of the body. /construction/parse
+// passes both signed and unsigned transactions under transaction.
+var redactedFields = map[string]bool{
+	"signed_transaction":   true,
+	"unsigned_transaction": true,
+	"transaction":          true,
+	"payloads":             true,
+	"signatures":           true,
+}
+
+// Middleware logs every request along with its status and duration, and
+// attaches a logger tagged with the request id to the request context.
+// Request bodies are logged at debug level, with transactions, signing
+// payloads and signatures redacted.
+func Middleware(log *zap.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		reqLog := log.With(zap.String("request_id", requestID))
+		if ce := reqLog.Check(zap.DebugLevel, "request body"); ce != nil {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				reqLog.Warn("unable to read request body", zap.Error(err))
+			}
+			r.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+			ce.Write(
+				zap.String("path", r.URL.Path),
+				zap.ByteString("body", redact(bytes.TrimSpace(body))),
+			)
+		}
+
+		rw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, r.WithContext(WithContext(r.Context(), reqLog)))
+
+		reqLog.Info("request",
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.Int("status", rw.status),
+			zap.Duration("duration", time.Since(start)),
+		)
+	})
+}
+
+// redact hides the redactedFields of a request body. Bodies that are not
+// JSON objects are left out entirely.
+func redact(body []byte) []byte {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return []byte(redacted)
+	}
+	redactValue(fields)
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return []byte(redacted)
+	}
+	return b
+}
+
+// redactValue replaces the redactedFields of the objects nested in value
+func redactValue(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if redactedFields[key] {
+				v[key] = redacted
+				continue
+			}
+			redactValue(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			redactValue(item)
+		}
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// statusWriter records the status code of a response
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
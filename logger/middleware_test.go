@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestMiddleware(t *testing.T) {
+	newHandler := func(level zapcore.Level) (http.Handler, *observer.ObservedLogs) {
+		core, logs := observer.New(level)
+		handler := Middleware(zap.New(core), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			FromContext(r.Context()).Info("handled", zap.ByteString("body", body))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		return handler, logs
+	}
+
+	t.Run("request id is generated", func(t *testing.T) {
+		handler, logs := newHandler(zapcore.InfoLevel)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/network/list", strings.NewReader("{}")))
+
+		requestID := w.Header().Get(RequestIDHeader)
+		assert.Len(t, requestID, 16)
+		assert.Equal(t, 2, logs.Len())
+		for _, entry := range logs.All() {
+			assert.Equal(t, requestID, entry.ContextMap()["request_id"])
+		}
+
+		entry := logs.FilterMessage("request").All()[0]
+		assert.Equal(t, "/network/list", entry.ContextMap()["path"])
+		assert.Equal(t, int64(http.StatusAccepted), entry.ContextMap()["status"])
+	})
+
+	t.Run("request id is forwarded", func(t *testing.T) {
+		handler, logs := newHandler(zapcore.InfoLevel)
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/block", strings.NewReader("{}"))
+		r.Header.Set(RequestIDHeader, "abc")
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, "abc", w.Header().Get(RequestIDHeader))
+		assert.Equal(t, "abc", logs.All()[0].ContextMap()["request_id"])
+	})
+
+	t.Run("bodies are logged at debug level", func(t *testing.T) {
+		handler, logs := newHandler(zapcore.DebugLevel)
+		body := `{"signed_transaction":"0xf86b","network_identifier":{"network":"Fuji"}}`
+
+		handler.ServeHTTP(
+			httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodPost, "/construction/submit", strings.NewReader(body)),
+		)
+
+		assert.Equal(
+			t,
+			`{"network_identifier":{"network":"Fuji"},"signed_transaction":"[redacted]"}`,
+			logs.FilterMessage("request body").All()[0].ContextMap()["body"],
+		)
+		// The handler still gets the body
+		assert.Equal(t, body, logs.FilterMessage("handled").All()[0].ContextMap()["body"])
+	})
+
+	t.Run("transactions, payloads and signatures are redacted", func(t *testing.T) {
+		assert.Equal(
+			t,
+			`{"signed":true,"transaction":"[redacted]"}`,
+			string(redact([]byte(`{"signed":true,"transaction":"0xf86b"}`))),
+		)
+		assert.Equal(
+			t,
+			`{"signed":false,"transaction":"[redacted]"}`,
+			string(redact([]byte(`{"signed":false,"transaction":"0xf86b"}`))),
+		)
+		assert.Equal(
+			t,
+			`{"signatures":"[redacted]","unsigned_transaction":"[redacted]"}`,
+			string(redact([]byte(`{"unsigned_transaction":"0xf86b","signatures":[{"hex_bytes":"00"}]}`))),
+		)
+		assert.Equal(
+			t,
+			`{"payloads":"[redacted]","unsigned_transaction":"[redacted]"}`,
+			string(redact([]byte(`{"unsigned_transaction":"0xf86b","payloads":[{"hex_bytes":"00"}]}`))),
+		)
+		// /call parameters are redacted as well
+		assert.Equal(
+			t,
+			`{"method":"rosetta.simulateTransaction","parameters":{"unsigned_transaction":"[redacted]"}}`,
+			string(redact([]byte(
+				`{"method":"rosetta.simulateTransaction","parameters":{"unsigned_transaction":"0xf86b"}}`,
+			))),
+		)
+		assert.Equal(t, redacted, string(redact([]byte("0xf86b"))))
+	})
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel(LevelWarn)
+	assert.NoError(t, err)
+	assert.Equal(t, zapcore.WarnLevel, level)
+
+	_, err = ParseLevel("verbose")
+	assert.ErrorIs(t, err, errInvalidLevel)
+}
@@ -3,6 +3,7 @@ package cchainatomictx
 import (
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 
 	"github.com/ava-labs/avalanche-rosetta/mapper"
 )
@@ -14,6 +15,16 @@ func IsCChainBech32Address(accountIdentifier *types.AccountIdentifier) bool {
 	return false
 }
 
+// IsSharedMemorySubAccount checks whether the account is a hex C-chain address
+// requesting its shared memory sub account
+func IsSharedMemorySubAccount(accountIdentifier *types.AccountIdentifier) bool {
+	if accountIdentifier == nil || accountIdentifier.SubAccount == nil {
+		return false
+	}
+	return accountIdentifier.SubAccount.Address == SubAccountTypeSharedMemory &&
+		ethcommon.IsHexAddress(accountIdentifier.Address)
+}
+
 func IsAtomicOpType(t string) bool {
 	atomicTypes := []string{
 		mapper.OpExport,
@@ -10,6 +10,14 @@ const (
 	MetadataAtomicTxGas = "atomic_tx_gas"
 	MetadataNonce       = "nonce"
 	MetadataSourceChain = "source_chain"
+
+	// SubAccountTypeSharedMemory is the sub account of hex C-chain addresses holding
+	// the atomic UTXOs importable by the bech32 address of the same key
+	SubAccountTypeSharedMemory = "shared_memory"
+	// MetadataPublicKey is the hex encoded compressed public key of the account
+	MetadataPublicKey = "public_key"
+	// MetadataBech32Address is the bech32 C-chain address of the account, checked against the public key
+	MetadataBech32Address = "bech32_address"
 )
 
 type Metadata struct {
@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,12 +10,20 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
 
+var (
+	errInvalidPublicKey     = errors.New("invalid public key")
+	errUnsupportedCurveType = errors.New("unsupported curve type")
+)
+
 // EqualFoldContains checks if the array contains the string regardless of casing
 func EqualFoldContains(arr []string, str string) bool {
 	for _, a := range arr {
@@ -95,3 +104,56 @@ func EncodeBytes(bytes []byte) (string, error) {
 func DecodeToBytes(binaryData string) ([]byte, error) {
 	return formatting.Decode(formatting.Hex, binaryData)
 }
+
+// ValidateCurveType rejects the curve types other than secp256k1, as no
+// C, P or X-chain address can be derived from them
+func ValidateCurveType(curveType types.CurveType) error {
+	if curveType != "" && curveType != types.Secp256k1 {
+		return fmt.Errorf("%w %s, only %s keys are supported", errUnsupportedCurveType, curveType, types.Secp256k1)
+	}
+	return nil
+}
+
+// ParsePublicKey parses a compressed or uncompressed secp256k1 public key.
+// Other curve types are rejected by ValidateCurveType.
+func ParsePublicKey(publicKey *types.PublicKey) (*ecdsa.PublicKey, error) {
+	if err := ValidateCurveType(publicKey.CurveType); err != nil {
+		return nil, err
+	}
+
+	var (
+		key *ecdsa.PublicKey
+		err error
+	)
+	if len(publicKey.Bytes) == 65 {
+		key, err = ethcrypto.UnmarshalPubkey(publicKey.Bytes)
+	} else {
+		key, err = ethcrypto.DecompressPubkey(publicKey.Bytes)
+	}
+	if err != nil {
+		return nil, errInvalidPublicKey
+	}
+	return key, nil
+}
+
+// DeriveAddresses returns the hex EVM address and the bech32 C, P and X chain
+// addresses of key on the network, keyed by chain alias and hex format
+func DeriveAddresses(networkIdentifier *types.NetworkIdentifier, key *ecdsa.PublicKey) (map[string]string, error) {
+	hrp, err := GetHRP(networkIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	shortID := hashing.PubkeyBytesToAddress(ethcrypto.CompressPubkey(key))
+	addresses := map[string]string{
+		AddressFormatHex: ethcrypto.PubkeyToAddress(*key).Hex(),
+	}
+	for _, chain := range []string{CChainNetworkIdentifier, PChainNetworkIdentifier, XChainNetworkIdentifier} {
+		addr, err := address.Format(chain, hrp, shortID)
+		if err != nil {
+			return nil, err
+		}
+		addresses[chain] = addr
+	}
+	return addresses, nil
+}
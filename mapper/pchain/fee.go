@@ -0,0 +1,65 @@
+package pchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+var (
+	errUnknownFeeTxType = errors.New("no fee is known for tx type")
+	errInsufficientFee  = errors.New("operations do not burn the tx fee")
+)
+
+// BurnsTxFee reports whether a tx of type opType burns a fee set by the node.
+// Adding a validator or a delegator to the primary network is free on every
+// network, as the node has no setting for the add staker tx fee.
+func BurnsTxFee(opType string) bool {
+	switch opType {
+	case OpAddValidator, OpAddDelegator:
+		return false
+	default:
+		return true
+	}
+}
+
+// TxFee returns the fee, in nAVAX, burned by a tx of type opType. P-chain fees
+// are flat per tx type and do not depend on the tx size.
+func TxFee(opType string, fees *info.GetTxFeeResponse) (uint64, error) {
+	switch opType {
+	case OpAddValidator, OpAddDelegator:
+		return 0, nil
+	case OpImportAvax, OpExportAvax, OpAddSubnetValidator:
+		return uint64(fees.TxFee), nil
+	case OpCreateSubnet:
+		return uint64(fees.CreateSubnetTxFee), nil
+	case OpCreateChain:
+		return uint64(fees.CreateBlockchainTxFee), nil
+	default:
+		return 0, fmt.Errorf("%w: %s", errUnknownFeeTxType, opType)
+	}
+}
+
+// CheckBurnedFee makes sure the inputs spent by operations exceed the outputs
+// they create by at least fee
+func CheckBurnedFee(operations []*types.Operation, fee uint64) error {
+	burned := new(big.Int)
+	for _, op := range operations {
+		if op.Amount == nil {
+			continue
+		}
+		amount, err := types.AmountValue(op.Amount)
+		if err != nil {
+			return err
+		}
+		burned.Sub(burned, amount)
+	}
+
+	if burned.Cmp(new(big.Int).SetUint64(fee)) < 0 {
+		return fmt.Errorf("%w: %s nAVAX burned, %d nAVAX required", errInsufficientFee, burned, fee)
+	}
+	return nil
+}
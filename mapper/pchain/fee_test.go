@@ -0,0 +1,49 @@
+package pchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+)
+
+func TestTxFee(t *testing.T) {
+	fees := &info.GetTxFeeResponse{
+		TxFee:                 1_000_000,
+		CreateSubnetTxFee:     100_000_000,
+		CreateBlockchainTxFee: 200_000_000,
+	}
+
+	for opType, expected := range map[string]uint64{
+		OpImportAvax:         1_000_000,
+		OpExportAvax:         1_000_000,
+		OpAddSubnetValidator: 1_000_000,
+		OpCreateSubnet:       100_000_000,
+		OpCreateChain:        200_000_000,
+		OpAddValidator:       0,
+		OpAddDelegator:       0,
+	} {
+		fee, err := TxFee(opType, fees)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, fee, opType)
+		assert.Equal(t, expected != 0, BurnsTxFee(opType), opType)
+	}
+
+	_, err := TxFee(OpRewardValidator, fees)
+	assert.ErrorIs(t, err, errUnknownFeeTxType)
+}
+
+func TestCheckBurnedFee(t *testing.T) {
+	operations := []*types.Operation{
+		{Amount: mapper.AtomicAvaxAmount(big.NewInt(-1_000))},
+		{Amount: mapper.AtomicAvaxAmount(big.NewInt(900))},
+		{Amount: nil},
+	}
+
+	assert.Nil(t, CheckBurnedFee(operations, 100))
+	assert.ErrorIs(t, CheckBurnedFee(operations, 101), errInsufficientFee)
+}
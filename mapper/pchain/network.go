@@ -0,0 +1,67 @@
+package pchain
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+)
+
+// NetworkConstants are the network values P-chain transactions commit to.
+// They never change for a given network, so knowing them lets transactions be
+// built without querying a node.
+type NetworkConstants struct {
+	NetworkID uint32
+	// BlockchainIDs maps chain aliases to their blockchain id
+	BlockchainIDs map[string]ids.ID
+}
+
+var (
+	MainnetConstants = &NetworkConstants{
+		NetworkID: constants.MainnetID,
+		BlockchainIDs: map[string]ids.ID{
+			mapper.PChainNetworkIdentifier: constants.PlatformChainID,
+			mapper.XChainNetworkIdentifier: mustParseID("2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM"),
+			mapper.CChainNetworkIdentifier: mustParseID("2q9e4r6Mu3U68nU1fYjgbR6JvwrRx36CohpAX5UQxse55x1Q5"),
+		},
+	}
+
+	FujiConstants = &NetworkConstants{
+		NetworkID: constants.FujiID,
+		BlockchainIDs: map[string]ids.ID{
+			mapper.PChainNetworkIdentifier: constants.PlatformChainID,
+			mapper.XChainNetworkIdentifier: mustParseID("2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm"),
+			mapper.CChainNetworkIdentifier: mustParseID("yH8D7ThNJkxmtkuv2jgBa4P1Rn3Qpr4pPr7QYNfcdoS6k6HWp"),
+		},
+	}
+)
+
+// GetNetworkConstants returns the constants of the network whose C-chain
+// has the given EVM chain id, or nil if the network is not a public one
+func GetNetworkConstants(chainID int64) *NetworkConstants {
+	switch chainID {
+	case mapper.MainnetChainID:
+		return MainnetConstants
+	case mapper.FujiChainID:
+		return FujiConstants
+	default:
+		return nil
+	}
+}
+
+// BlockchainID returns the blockchain id of chain, if known
+func (c *NetworkConstants) BlockchainID(chain string) (ids.ID, bool) {
+	if c == nil {
+		return ids.Empty, false
+	}
+	chainID, ok := c.BlockchainIDs[chain]
+	return chainID, ok
+}
+
+func mustParseID(id string) ids.ID {
+	parsed, err := ids.FromString(id)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
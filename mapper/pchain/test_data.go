@@ -36,7 +36,7 @@ func buildImport() (*platformvm.UnsignedImportTx, map[string]*types.AccountIdent
 						},
 					},
 				},
-					{ //  this will be skipped as it is multisig
+					{ // multisig output
 						Asset: avax.Asset{ID: avaxAssetID},
 						FxID:  [32]byte{},
 						Out: &secp256k1fx.TransferOutput{
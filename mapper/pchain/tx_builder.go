@@ -17,7 +17,10 @@ import (
 	"github.com/ava-labs/avalanche-rosetta/mapper"
 )
 
-var errInvalidMetadata = errors.New("invalid metadata")
+var (
+	errInvalidMetadata = errors.New("invalid metadata")
+	errInvalidSigIndex = errors.New("signature index out of owners range")
+)
 
 func BuildTx(
 	opType string,
@@ -263,6 +266,7 @@ func buildInputs(
 	signers []*types.AccountIdentifier,
 	err error,
 ) {
+	inputSigners := make(map[string][]*types.AccountIdentifier, len(operations))
 	for _, op := range operations {
 		UTXOID, err := mapper.DecodeUTXOID(op.CoinChange.CoinIdentifier.Identifier)
 		if err != nil {
@@ -304,15 +308,58 @@ func buildInputs(
 		default:
 			return nil, nil, nil, fmt.Errorf("invalid option type: %s", op.Type)
 		}
-		signers = append(signers, op.Account)
+
+		// multisig inputs need one signature per signature index
+		if len(opMetadata.Owners) > 0 {
+			msigSigners, err := MultisigSigners(opMetadata.Owners, opMetadata.SigIndices)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			inputSigners[in.UTXOID.String()] = msigSigners
+		} else {
+			inputSigners[in.UTXOID.String()] = []*types.AccountIdentifier{op.Account}
+		}
 	}
 
 	avax.SortTransferableInputs(ins)
 	avax.SortTransferableInputs(imported)
 
+	// signers must follow the sorted input order as credentials are built in that order
+	for _, inputs := range [][]*avax.TransferableInput{ins, imported} {
+		for _, in := range inputs {
+			signers = append(signers, inputSigners[in.UTXOID.String()]...)
+		}
+	}
+
 	return ins, imported, signers, nil
 }
 
+// MultisigSigners returns the accounts that have to sign an input spending a UTXO owned by owners.
+// Output owner addresses are stored sorted, so sigIndices are resolved against the sorted owner list.
+func MultisigSigners(owners []string, sigIndices []uint32) ([]*types.AccountIdentifier, error) {
+	addrs := make([]ids.ShortID, 0, len(owners))
+	ownerByAddr := make(map[ids.ShortID]string, len(owners))
+	for _, owner := range owners {
+		addr, err := address.ParseToID(owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse owner address: %w", err)
+		}
+		addrs = append(addrs, addr)
+		ownerByAddr[addr] = owner
+	}
+	ids.SortShortIDs(addrs)
+
+	signers := make([]*types.AccountIdentifier, 0, len(sigIndices))
+	for _, sigIndex := range sigIndices {
+		if int(sigIndex) >= len(addrs) {
+			return nil, errInvalidSigIndex
+		}
+		signers = append(signers, &types.AccountIdentifier{Address: ownerByAddr[addrs[sigIndex]]})
+	}
+
+	return signers, nil
+}
+
 func ParseOpMetadata(metadata map[string]interface{}) (*OperationMetadata, error) {
 	var operationMetadata OperationMetadata
 	if err := mapper.UnmarshalJSONMap(metadata, &operationMetadata); err != nil {
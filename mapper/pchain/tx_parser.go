@@ -2,7 +2,7 @@ package pchain
 
 import (
 	"errors"
-	"log"
+	"fmt"
 	"math/big"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanche-rosetta/mapper"
 )
@@ -92,7 +93,7 @@ func (t *TxParser) Parse(tx platformvm.UnsignedTx) (*types.Transaction, error) {
 		txType = OpAdvanceTime
 		// no op tx
 	default:
-		log.Printf("unknown type %T", unsignedTx)
+		zap.L().Warn("unknown tx type", zap.String("type", fmt.Sprintf("%T", unsignedTx)))
 	}
 	if err != nil {
 		return nil, err
@@ -319,23 +320,27 @@ func (t *TxParser) insToOperations(
 			metadata.SigIndices = transferInput.SigIndices
 		}
 
-		opMetadata, err := mapper.MarshalJSONMap(metadata)
-		if err != nil {
-			return nil, err
-		}
-
 		// If dependency txs are provided, which is the case for /block endpoints
-		// check whether the input UTXO is multisig. If so, skip it.
+		// attach the owners of multisig input UTXOs
 		if t.dependencyTxs != nil {
-			isMultisig, err := t.isMultisig(in.UTXOID)
+			owners, err := t.utxoOwners(in.UTXOID)
 			if err != nil {
 				return nil, errFailedToCheckMultisig
 			}
-			if isMultisig {
-				continue
+			if len(owners.Addrs) > 1 {
+				metadata.Owners, err = formatOwners(mapper.PChainNetworkIdentifier, t.hrp, owners.Addrs)
+				if err != nil {
+					return nil, err
+				}
+				metadata.Threshold = owners.Threshold
 			}
 		}
 
+		opMetadata, err := mapper.MarshalJSONMap(metadata)
+		if err != nil {
+			return nil, err
+		}
+
 		utxoID := in.UTXOID.String()
 		account, ok := t.inputTxAccounts[utxoID]
 		if !ok {
@@ -396,13 +401,6 @@ func (t *TxParser) outsToOperations(
 			return nil, nil, errOutputTypeAssertion
 		}
 
-		// Rosetta cannot handle multisig at the moment. In order to pass data validation,
-		// we treat multisig outputs like a burn and inputs line a mint and therefore
-		// not include them in the operations
-		if len(transferOutput.Addrs) != 1 {
-			continue
-		}
-
 		outOp, err := t.buildOutputOperation(
 			transferOutput,
 			status,
@@ -455,13 +453,6 @@ func (t *TxParser) utxosToOperations(
 			return nil, nil, errOutputTypeAssertion
 		}
 
-		// Rosetta cannot handle multisig at the moment. In order to pass data validation,
-		// we treat multisig outputs like a burn and inputs line a mint and therefore
-		// not include them in the operations
-		if len(out.Addrs) != 1 {
-			continue
-		}
-
 		outOp, err := t.buildOutputOperation(
 			out,
 			status,
@@ -511,6 +502,17 @@ func (t *TxParser) buildOutputOperation(
 		Locktime:  out.OutputOwners.Locktime,
 	}
 
+	account := &types.AccountIdentifier{Address: outAddrFormat}
+
+	// multisig outputs are credited to the multisig sub account of their first owner
+	if len(out.Addrs) > 1 {
+		metadata.Owners, err = formatOwners(chainIDAlias, t.hrp, out.Addrs)
+		if err != nil {
+			return nil, err
+		}
+		account.SubAccount = &types.SubAccountIdentifier{Address: SubAccountTypeMultisig}
+	}
+
 	opMetadata, err := mapper.MarshalJSONMap(metadata)
 	if err != nil {
 		return nil, err
@@ -537,28 +539,51 @@ func (t *TxParser) buildOutputOperation(
 		},
 		CoinChange: coinChange,
 		Status:     status,
-		Account:    &types.AccountIdentifier{Address: outAddrFormat},
+		Account:    account,
 		Amount:     mapper.AtomicAvaxAmount(outBigAmount),
 		Metadata:   opMetadata,
 	}, nil
 }
 
-func (t *TxParser) isMultisig(utxoid avax.UTXOID) (bool, error) {
+func (t *TxParser) utxoOwners(utxoid avax.UTXOID) (*secp256k1fx.OutputOwners, error) {
 	dependencyTx, ok := t.dependencyTxs[utxoid.TxID.String()]
 	if !ok {
-		return false, errFailedToCheckMultisig
+		return nil, errFailedToCheckMultisig
 	}
 
 	utxoMap := getUTXOMap(dependencyTx)
 	utxo, ok := utxoMap[utxoid.OutputIndex]
 	if !ok {
-		return false, errFailedToCheckMultisig
+		return nil, errFailedToCheckMultisig
+	}
+
+	return outputOwners(utxo.Out)
+}
+
+// outputOwners returns the owners of a transfer output, unwrapping stakeable locked outputs
+func outputOwners(outIntf interface{}) (*secp256k1fx.OutputOwners, error) {
+	if lockOut, ok := outIntf.(*stakeable.LockOut); ok {
+		outIntf = lockOut.TransferableOut
 	}
 
-	addressable, ok := utxo.Out.(avax.Addressable)
-	isMultisig := len(addressable.Addresses()) != 1
+	out, ok := outIntf.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return nil, errFailedToGetUTXOAddresses
+	}
 
-	return isMultisig, nil
+	return &out.OutputOwners, nil
+}
+
+func formatOwners(chainIDAlias string, hrp string, addrs []ids.ShortID) ([]string, error) {
+	owners := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		owner, err := address.Format(chainIDAlias, hrp, addr[:])
+		if err != nil {
+			return nil, err
+		}
+		owners = append(owners, owner)
+	}
+	return owners, nil
 }
 
 func GetAccountsFromUTXOs(hrp string, dependencyTxs map[string]*DependencyTx) (map[string]*types.AccountIdentifier, error) {
@@ -567,22 +592,25 @@ func GetAccountsFromUTXOs(hrp string, dependencyTxs map[string]*DependencyTx) (m
 		utxoMap := getUTXOMap(dependencyTx)
 
 		for _, utxo := range utxoMap {
-			addressable, ok := utxo.Out.(avax.Addressable)
-			if !ok {
-				return nil, errFailedToGetUTXOAddresses
+			owners, err := outputOwners(utxo.Out)
+			if err != nil {
+				return nil, err
 			}
-
-			addrs := addressable.Addresses()
-
-			if len(addrs) != 1 {
-				continue
+			if len(owners.Addrs) == 0 {
+				return nil, errNoOutputAddresses
 			}
 
-			addr, err := address.Format(mapper.PChainNetworkIdentifier, hrp, addrs[0][:])
-			addresses[utxo.UTXOID.String()] = &types.AccountIdentifier{Address: addr}
+			addr, err := address.Format(mapper.PChainNetworkIdentifier, hrp, owners.Addrs[0][:])
 			if err != nil {
 				return nil, err
 			}
+
+			account := &types.AccountIdentifier{Address: addr}
+			// multisig UTXOs are spent from the multisig sub account of their first owner
+			if len(owners.Addrs) > 1 {
+				account.SubAccount = &types.SubAccountIdentifier{Address: SubAccountTypeMultisig}
+			}
+			addresses[utxo.UTXOID.String()] = account
 		}
 	}
 
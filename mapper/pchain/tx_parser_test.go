@@ -6,6 +6,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/stretchr/testify/assert"
 
@@ -141,18 +143,55 @@ func TestMapImportTx(t *testing.T) {
 	rosettaTransaction, err := parser.Parse(importTx)
 	assert.Nil(t, err)
 
-	total := len(importTx.Ins) + len(importTx.Outs) + len(importTx.ImportedInputs) - 1 // - 1 for the multisig output
+	total := len(importTx.Ins) + len(importTx.Outs) + len(importTx.ImportedInputs)
 	assert.Equal(t, total, len(rosettaTransaction.Operations))
 
 	cntTxType, cntInputMeta, cntOutputMeta, cntMetaType := verifyRosettaTransaction(rosettaTransaction.Operations, OpImportAvax, OpTypeImport)
 
-	assert.Equal(t, 2, cntTxType)
+	assert.Equal(t, 3, cntTxType)
 	assert.Equal(t, 0, cntInputMeta)
-	assert.Equal(t, 1, cntOutputMeta)
+	assert.Equal(t, 2, cntOutputMeta)
 	assert.Equal(t, 1, cntMetaType)
 
 	assert.Equal(t, types.CoinSpent, rosettaTransaction.Operations[0].CoinChange.CoinAction)
 	assert.Nil(t, rosettaTransaction.Operations[1].CoinChange)
+
+	// the multisig output is credited to the multisig sub account of its first owner
+	multisigOp := rosettaTransaction.Operations[2]
+	assert.Equal(t, "P-fuji1xm0r37l6gyf2mly4pmzc0tz6wnwqkugedh95fk", multisigOp.Account.Address)
+	assert.Equal(t, SubAccountTypeMultisig, multisigOp.Account.SubAccount.Address)
+	assert.Len(t, multisigOp.Metadata[MetadataOwners], 3)
+	assert.Equal(t, float64(2), multisigOp.Metadata[MetadataThreshold])
+}
+
+func TestMapMultisigInput(t *testing.T) {
+	importTx, _ := buildImport()
+	dependencyTxs := map[string]*DependencyTx{
+		importTx.ID().String(): {ID: importTx.ID(), Tx: &platformvm.Tx{UnsignedTx: importTx}},
+	}
+
+	inputAccounts, err := GetAccountsFromUTXOs(constants.FujiHRP, dependencyTxs)
+	assert.Nil(t, err)
+
+	multisigIn := &avax.TransferableInput{
+		UTXOID: avax.UTXOID{TxID: importTx.ID(), OutputIndex: 1},
+		In: &secp256k1fx.TransferInput{
+			Amt:   8000000,
+			Input: secp256k1fx.Input{SigIndices: []uint32{0, 1}},
+		},
+	}
+
+	parser := NewTxParser(false, constants.FujiHRP, chainIDs, inputAccounts, dependencyTxs)
+	rosettaInOp, err := parser.insToOperations(0, OpExportAvax, []*avax.TransferableInput{multisigIn}, OpTypeInput)
+	assert.Nil(t, err)
+	assert.Len(t, rosettaInOp, 1)
+
+	assert.Equal(t, multisigIn.UTXOID.String(), rosettaInOp[0].CoinChange.CoinIdentifier.Identifier)
+	assert.Equal(t, "P-fuji1xm0r37l6gyf2mly4pmzc0tz6wnwqkugedh95fk", rosettaInOp[0].Account.Address)
+	assert.Equal(t, SubAccountTypeMultisig, rosettaInOp[0].Account.SubAccount.Address)
+	assert.Equal(t, "-8000000", rosettaInOp[0].Amount.Value)
+	assert.Len(t, rosettaInOp[0].Metadata[MetadataOwners], 3)
+	assert.Equal(t, float64(2), rosettaInOp[0].Metadata[MetadataThreshold])
 }
 
 func TestNonConstructionMapExportTx(t *testing.T) {
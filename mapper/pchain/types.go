@@ -4,6 +4,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
 )
 
 const (
@@ -34,12 +36,22 @@ const (
 	MetadataVMID        = "vmid"
 	MetadataMemo        = "memo"
 	MetadataMessage     = "message"
+	MetadataOwners      = "owners"
+	MetadataThreshold   = "threshold"
+	MetadataLocktime    = "locktime"
 
 	SubAccountTypeSharedMemory       = "shared_memory"
 	SubAccountTypeUnlocked           = "unlocked"
 	SubaccounttypelockedStakeable    = "locked_stakeable"
 	SubaccounttypelockedNotStakeable = "locked_not_stakeable"
 	SubAccountTypeStaked             = "staked"
+	SubAccountTypeMultisig           = "multisig"
+
+	CallGetCurrentValidators = "platform.getCurrentValidators"
+	CallGetPendingValidators = "platform.getPendingValidators"
+	CallGetStake             = "platform.getStake"
+	CallGetRewardUTXOs       = "platform.getRewardUTXOs"
+	CallGetMinStake          = "platform.getMinStake"
 )
 
 var (
@@ -53,7 +65,15 @@ var (
 		OpCreateSubnet,
 		OpAddSubnetValidator,
 	}
-	CallMethods = []string{}
+	CallMethods = []string{
+		CallGetCurrentValidators,
+		CallGetPendingValidators,
+		CallGetStake,
+		CallGetRewardUTXOs,
+		CallGetMinStake,
+		mapper.CallGetSubmittedTransactionStatus,
+		mapper.CallSelectUTXOs,
+	}
 )
 
 type OperationMetadata struct {
@@ -62,6 +82,10 @@ type OperationMetadata struct {
 	Locktime    uint64   `json:"locktime"`
 	Threshold   uint32   `json:"threshold,omitempty"`
 	StakingTxID string   `json:"staking_tx_id,omitempty"`
+
+	// Owners lists the addresses controlling a multisig UTXO spent by an input.
+	// SigIndices refer to positions in the sorted owner list.
+	Owners []string `json:"owners,omitempty"`
 }
 
 type ImportExportOptions struct {
@@ -69,6 +93,13 @@ type ImportExportOptions struct {
 	DestinationChain string `json:"destination_chain"`
 }
 
+// FeeOptions may be passed along with the options of any tx type
+type FeeOptions struct {
+	// Fee is the tx fee in nAVAX. Online, it may not be lower than the fee
+	// reported by the node. Offline, it is required for txs burning a fee.
+	Fee *uint64 `json:"fee,omitempty"`
+}
+
 type StakingOptions struct {
 	NodeID          string   `json:"node_id"`
 	Start           uint64   `json:"start"`
@@ -83,6 +114,8 @@ type StakingOptions struct {
 type Metadata struct {
 	NetworkID    uint32 `json:"network_id"`
 	BlockchainID ids.ID `json:"blockchain_id"`
+	// Fee is the amount, in nAVAX, the tx must burn
+	Fee uint64 `json:"fee,omitempty"`
 	*ImportMetadata
 	*ExportMetadata
 	*StakingMetadata
@@ -108,6 +141,24 @@ type StakingMetadata struct {
 	Memo            string   `json:"memo"`
 }
 
+// ValidatorsCallInput is the input to the call methods
+// "platform.getCurrentValidators" and "platform.getPendingValidators".
+// Validators of the primary network are returned if no subnet id is provided.
+type ValidatorsCallInput struct {
+	SubnetID string   `json:"subnet_id,omitempty"`
+	NodeIDs  []string `json:"node_ids,omitempty"`
+}
+
+// StakeCallInput is the input to the call method "platform.getStake".
+type StakeCallInput struct {
+	Addresses []string `json:"addresses"`
+}
+
+// RewardUTXOsCallInput is the input to the call method "platform.getRewardUTXOs".
+type RewardUTXOsCallInput struct {
+	TxID string `json:"tx_id"`
+}
+
 type DependencyTx struct {
 	ID          ids.ID
 	Tx          *platformvm.Tx
@@ -1,8 +1,8 @@
 package mapper
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"math/big"
 	"strconv"
 	"strings"
@@ -28,6 +28,8 @@ const (
 var (
 	X2crate     = big.NewInt(1000000000)
 	zeroAddress = common.Address{}
+
+	errNegativeDestroyedBalance = errors.New("negative balance for suicided account")
 )
 
 func Transaction(
@@ -77,7 +79,10 @@ func Transaction(
 
 	ops = append(ops, feeOps...)
 
-	traceOps := traceOps(flattenedTrace, len(feeOps))
+	traceOps, err := traceOps(flattenedTrace, len(feeOps))
+	if err != nil {
+		return nil, err
+	}
 	ops = append(ops, traceOps...)
 	for _, log := range receipt.Logs {
 		// Only check transfer logs
@@ -134,6 +139,40 @@ func Transaction(
 	}, nil
 }
 
+// SimulatedOperations returns the operations a transaction is expected to
+// produce given the trace of its simulation and the logs it would emit. Fee
+// operations are left out since the fee receiver is only known once the
+// transaction is included in a block.
+func SimulatedOperations(
+	flattenedTrace []*clientTypes.FlatCall,
+	logs []*ethtypes.Log,
+	client clientTypes.Client,
+) ([]*types.Operation, error) {
+	ops, err := traceOps(flattenedTrace, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range logs {
+		if len(log.Topics) == 0 || log.Topics[0].String() != transferMethodHash {
+			continue
+		}
+
+		switch len(log.Topics) {
+		case topicsInErc721Transfer:
+			ops = append(ops, erc721Ops(log, int64(len(ops)))...)
+		case topicsInErc20Transfer:
+			symbol, decimals, err := client.GetContractInfo(log.Address, true)
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, erc20Ops(log, ToCurrency(symbol, decimals, log.Address), int64(len(ops)))...)
+		default:
+		}
+	}
+
+	return ops, nil
+}
+
 func crossChainTransaction(
 	rawIdx int,
 	avaxAssetID string,
@@ -334,10 +373,10 @@ func MempoolTransactionsIDs(accountMap clientTypes.TxAccountMap) []*types.Transa
 	return result
 }
 
-func traceOps(trace []*clientTypes.FlatCall, startIndex int) []*types.Operation {
+func traceOps(trace []*clientTypes.FlatCall, startIndex int) ([]*types.Operation, error) {
 	ops := []*types.Operation{}
 	if len(trace) == 0 {
-		return ops
+		return ops, nil
 	}
 
 	destroyedAccounts := map[string]*big.Int{}
@@ -466,7 +505,7 @@ func traceOps(trace []*clientTypes.FlatCall, startIndex int) []*types.Operation
 		}
 
 		if val.Sign() < 0 {
-			log.Fatalf("negative balance for suicided account %s: %s\n", acct, val.String())
+			return nil, fmt.Errorf("%w %s: %s", errNegativeDestroyedBalance, acct, val.String())
 		}
 
 		ops = append(ops, &types.Operation{
@@ -485,7 +524,7 @@ func traceOps(trace []*clientTypes.FlatCall, startIndex int) []*types.Operation
 		})
 	}
 
-	return ops
+	return ops, nil
 }
 
 func erc20Ops(transferLog *ethtypes.Log, currency *types.Currency, opsLen int64) []*types.Operation {
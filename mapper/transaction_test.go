@@ -2,6 +2,8 @@ package mapper
 
 import (
 	"encoding/hex"
+	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -11,6 +13,8 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/types"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+
+	clientTypes "github.com/ava-labs/avalanche-rosetta/client"
 )
 
 var WAVAX = &types.Currency{
@@ -243,6 +247,32 @@ func TestERC721Ops(t *testing.T) {
 	})
 }
 
+func TestTraceOps(t *testing.T) {
+	destroyed := ethcommon.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+	beneficiary := ethcommon.HexToAddress("0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309")
+
+	t.Run("negative balance of a destroyed account", func(t *testing.T) {
+		trace := []*clientTypes.FlatCall{
+			{
+				Type:  OpSelfDestruct,
+				From:  destroyed,
+				To:    beneficiary,
+				Value: big.NewInt(0),
+			},
+			{
+				Type:  OpCall,
+				From:  destroyed,
+				To:    beneficiary,
+				Value: big.NewInt(5),
+			},
+		}
+
+		ops, err := traceOps(trace, 0)
+		assert.Nil(t, ops)
+		assert.True(t, errors.Is(err, errNegativeDestroyedBalance))
+	})
+}
+
 func TestCrossChainSkippedOs(t *testing.T) {
 	t.Run("Export tx skipped op", func(t *testing.T) {
 		var (
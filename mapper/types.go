@@ -17,6 +17,8 @@ const (
 
 	ContractAddressMetadata  = "contractAddress"
 	IndexTransferredMetadata = "indexTransferred"
+	BytecodeMetadata         = "bytecode"
+	ConstructorArgsMetadata  = "constructorArgs"
 
 	PChainNetworkIdentifier = "P"
 	CChainNetworkIdentifier = "C"
@@ -49,6 +51,24 @@ const (
 	MetaDestinationChainID = "destination_chain"
 	MetaAddressFormat      = "address_format"
 	AddressFormatBech32    = "bech32"
+	AddressFormatHex       = "hex"
+
+	// AddressFormatAll derives the hex and the bech32 C, P and X addresses of
+	// a public key, listed under MetaAddresses
+	AddressFormatAll = "all"
+	MetaAddresses    = "addresses"
+
+	// CallGetSubmittedTransactionStatus returns the status of a transaction
+	// submitted through this server, on either chain
+	CallGetSubmittedTransactionStatus = "rosetta.getSubmittedTransactionStatus"
+
+	// CallSelectUTXOs selects the UTXOs covering an amount and returns the
+	// operations spending them, for P-chain and C-chain atomic transactions
+	CallSelectUTXOs = "rosetta.selectUTXOs"
+
+	// CallSimulateTransaction runs an unsigned C-chain transaction on top of
+	// the latest block and returns the operations it is expected to produce
+	CallSimulateTransaction = "rosetta.simulateTransaction"
 )
 
 var (
@@ -106,10 +126,6 @@ var (
 		OpErc721Mint,
 		OpErc721Burn,
 	}
-
-	CallMethods = []string{
-		"eth_getTransactionReceipt",
-	}
 )
 
 func CallType(t string) bool {
@@ -13,6 +13,8 @@ import (
 
 	context "context"
 
+	evm "github.com/ava-labs/coreth/plugin/evm"
+
 	ids "github.com/ava-labs/avalanchego/ids"
 
 	info "github.com/ava-labs/avalanchego/api/info"
@@ -146,6 +148,59 @@ func (_m *Client) ChainID(_a0 context.Context) (*big.Int, error) {
 	return r0, r1
 }
 
+// CodeAt provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Client) CodeAt(_a0 context.Context, _a1 common.Address, _a2 *big.Int) ([]byte, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *big.Int) []byte); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, *big.Int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAccessList provides a mock function with given fields: _a0, _a1
+func (_m *Client) CreateAccessList(_a0 context.Context, _a1 interfaces.CallMsg) (*types.AccessList, uint64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *types.AccessList
+	if rf, ok := ret.Get(0).(func(context.Context, interfaces.CallMsg) *types.AccessList); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.AccessList)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, interfaces.CallMsg) uint64); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, interfaces.CallMsg) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // EstimateBaseFee provides a mock function with given fields: ctx
 func (_m *Client) EstimateBaseFee(ctx context.Context) (*big.Int, error) {
 	ret := _m.Called(ctx)
@@ -190,6 +245,50 @@ func (_m *Client) EstimateGas(_a0 context.Context, _a1 interfaces.CallMsg) (uint
 	return r0, r1
 }
 
+// FilterLogs provides a mock function with given fields: _a0, _a1
+func (_m *Client) FilterLogs(_a0 context.Context, _a1 interfaces.FilterQuery) ([]types.Log, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []types.Log
+	if rf, ok := ret.Get(0).(func(context.Context, interfaces.FilterQuery) []types.Log); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Log)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interfaces.FilterQuery) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAtomicTxStatus provides a mock function with given fields: ctx, txID
+func (_m *Client) GetAtomicTxStatus(ctx context.Context, txID ids.ID) (evm.Status, error) {
+	ret := _m.Called(ctx, txID)
+
+	var r0 evm.Status
+	if rf, ok := ret.Get(0).(func(context.Context, ids.ID) evm.Status); ok {
+		r0 = rf(ctx, txID)
+	} else {
+		r0 = ret.Get(0).(evm.Status)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ids.ID) error); ok {
+		r1 = rf(ctx, txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAtomicUTXOs provides a mock function with given fields: ctx, addrs, sourceChain, limit, startAddress, startUTXOID
 func (_m *Client) GetAtomicUTXOs(ctx context.Context, addrs []string, sourceChain string, limit uint32, startAddress string, startUTXOID string) ([][]byte, api.Index, error) {
 	ret := _m.Called(ctx, addrs, sourceChain, limit, startAddress, startUTXOID)
@@ -482,6 +581,27 @@ func (_m *Client) Peers(_a0 context.Context, _a1 ...rpc.Option) ([]info.Peer, er
 	return r0, r1
 }
 
+// PendingNonceAt provides a mock function with given fields: _a0, _a1
+func (_m *Client) PendingNonceAt(_a0 context.Context, _a1 common.Address) (uint64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) uint64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendTransaction provides a mock function with given fields: _a0, _a1
 func (_m *Client) SendTransaction(_a0 context.Context, _a1 *types.Transaction) error {
 	ret := _m.Called(_a0, _a1)
@@ -496,6 +616,29 @@ func (_m *Client) SendTransaction(_a0 context.Context, _a1 *types.Transaction) e
 	return r0
 }
 
+// StorageAt provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Client) StorageAt(_a0 context.Context, _a1 common.Address, _a2 common.Hash, _a3 *big.Int) ([]byte, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, common.Hash, *big.Int) []byte); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, common.Hash, *big.Int) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SuggestGasPrice provides a mock function with given fields: _a0
 func (_m *Client) SuggestGasPrice(_a0 context.Context) (*big.Int, error) {
 	ret := _m.Called(_a0)
@@ -519,6 +662,29 @@ func (_m *Client) SuggestGasPrice(_a0 context.Context) (*big.Int, error) {
 	return r0, r1
 }
 
+// SuggestGasTipCap provides a mock function with given fields: _a0
+func (_m *Client) SuggestGasTipCap(_a0 context.Context) (*big.Int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(context.Context) *big.Int); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TraceBlockByHash provides a mock function with given fields: _a0, _a1
 func (_m *Client) TraceBlockByHash(_a0 context.Context, _a1 string) ([]*client.Call, [][]*client.FlatCall, error) {
 	ret := _m.Called(_a0, _a1)
@@ -551,6 +717,38 @@ func (_m *Client) TraceBlockByHash(_a0 context.Context, _a1 string) ([]*client.C
 	return r0, r1, r2
 }
 
+// TraceCall provides a mock function with given fields: _a0, _a1
+func (_m *Client) TraceCall(_a0 context.Context, _a1 interfaces.CallMsg) (*client.Call, []*client.FlatCall, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *client.Call
+	if rf, ok := ret.Get(0).(func(context.Context, interfaces.CallMsg) *client.Call); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Call)
+		}
+	}
+
+	var r1 []*client.FlatCall
+	if rf, ok := ret.Get(1).(func(context.Context, interfaces.CallMsg) []*client.FlatCall); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*client.FlatCall)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, interfaces.CallMsg) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // TraceTransaction provides a mock function with given fields: _a0, _a1
 func (_m *Client) TraceTransaction(_a0 context.Context, _a1 string) (*client.Call, []*client.FlatCall, error) {
 	ret := _m.Called(_a0, _a1)
@@ -2,11 +2,19 @@ package cchainatomictx
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
+	"math/big"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/math"
+	ethtypes "github.com/ava-labs/coreth/core/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/ava-labs/avalanche-rosetta/service/backend/common"
 
@@ -15,35 +23,42 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/types"
 
 	"github.com/ava-labs/avalanche-rosetta/mapper"
+	cmapper "github.com/ava-labs/avalanche-rosetta/mapper/cchainatomictx"
 	"github.com/ava-labs/avalanche-rosetta/service"
 )
 
 var (
 	errUnableToParseUTXO  = errors.New("unable to parse UTXO")
 	errUnableToGetUTXOOut = errors.New("unable to get UTXO output")
+	errNoPublicKey        = errors.New("public_key must be provided in sub account metadata")
+	errPublicKeyMismatch  = errors.New("public key does not match account address")
+	errBech32Mismatch     = errors.New("bech32_address does not match public key")
+
+	errBlockAddedWhileFetching = errors.New("new blocks kept being added while fetching utxos")
+	errHistoricalBalance       = errors.New("atomic balances are only available at the current block")
 )
 
 func (b *Backend) AccountBalance(ctx context.Context, req *types.AccountBalanceRequest) (*types.AccountBalanceResponse, *types.Error) {
 	if req.AccountIdentifier == nil {
 		return nil, service.WrapError(service.ErrInvalidInput, "account identifier is not provided")
 	}
-	preHeader, err := service.BlockHeaderFromInput(ctx, b.cClient, req.BlockIdentifier)
-	if err != nil {
-		return nil, err
-	}
-
-	coins, wrappedErr := b.getAccountCoins(ctx, req.AccountIdentifier.Address)
+	atomicAddress, wrappedErr := b.getAtomicAddress(req.NetworkIdentifier, req.AccountIdentifier)
 	if wrappedErr != nil {
 		return nil, wrappedErr
 	}
 
-	postHeader, terr := service.BlockHeaderFromInput(ctx, b.cClient, req.BlockIdentifier)
-	if err != nil {
-		return nil, err
+	header, coins, wrappedErr := b.fetchCoinsSnapshot(ctx, atomicAddress)
+	if wrappedErr != nil {
+		return nil, wrappedErr
 	}
 
-	if postHeader.Number.Int64() != preHeader.Number.Int64() {
-		return nil, service.WrapError(service.ErrInternalError, "new block added while fetching utxos")
+	// Atomic UTXOs can't be looked up at a past height, so the requested
+	// block must be the one the snapshot was taken at
+	if req.BlockIdentifier != nil {
+		index, hash := req.BlockIdentifier.Index, req.BlockIdentifier.Hash
+		if (index != nil && *index != header.Number.Int64()) || (hash != nil && *hash != header.Hash().String()) {
+			return nil, service.WrapError(service.ErrInvalidInput, errHistoricalBalance)
+		}
 	}
 
 	var balanceValue uint64
@@ -60,13 +75,10 @@ func (b *Backend) AccountBalance(ctx context.Context, req *types.AccountBalanceR
 		}
 	}
 
-	if terr != nil {
-		return nil, terr
-	}
 	return &types.AccountBalanceResponse{
 		BlockIdentifier: &types.BlockIdentifier{
-			Index: postHeader.Number.Int64(),
-			Hash:  postHeader.Hash().String(),
+			Index: header.Number.Int64(),
+			Hash:  header.Hash().String(),
 		},
 		Balances: []*types.Amount{
 			{
@@ -81,19 +93,14 @@ func (b *Backend) AccountCoins(ctx context.Context, req *types.AccountCoinsReque
 	if req.AccountIdentifier == nil {
 		return nil, service.WrapError(service.ErrInvalidInput, "account identifier is not provided")
 	}
-	coins, wrappedErr := b.getAccountCoins(ctx, req.AccountIdentifier.Address)
+	atomicAddress, wrappedErr := b.getAtomicAddress(req.NetworkIdentifier, req.AccountIdentifier)
 	if wrappedErr != nil {
 		return nil, wrappedErr
 	}
 
-	// get the tip
-	blockHeader, err := b.cClient.HeaderByNumber(ctx, nil)
-	if err != nil {
-		return nil, service.WrapError(service.ErrInvalidInput, "unable to get tip")
-	}
-
-	if blockHeader == nil {
-		return nil, service.WrapError(service.ErrClientError, "latest block not found")
+	blockHeader, coins, wrappedErr := b.fetchCoinsSnapshot(ctx, atomicAddress)
+	if wrappedErr != nil {
+		return nil, wrappedErr
 	}
 
 	return &types.AccountCoinsResponse{
@@ -105,6 +112,134 @@ func (b *Backend) AccountCoins(ctx context.Context, req *types.AccountCoinsReque
 	}, nil
 }
 
+// getAtomicAddress returns the bech32 address whose atomic UTXOs are reported for the account.
+// Hex addresses are mapped to the bech32 address of the same key, derived from the public key
+// provided in the shared memory sub account metadata. Both addresses are checked against the key,
+// so a bech32 address, when also provided, must belong to it.
+func (b *Backend) getAtomicAddress(
+	networkIdentifier *types.NetworkIdentifier,
+	accountIdentifier *types.AccountIdentifier,
+) (string, *types.Error) {
+	if !cmapper.IsSharedMemorySubAccount(accountIdentifier) {
+		return accountIdentifier.Address, nil
+	}
+
+	metadata := accountIdentifier.SubAccount.Metadata
+
+	publicKey, ok := metadata[cmapper.MetadataPublicKey].(string)
+	if !ok {
+		return "", service.WrapError(service.ErrInvalidInput, errNoPublicKey)
+	}
+
+	pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(publicKey, "0x"))
+	if err != nil {
+		return "", service.WrapError(service.ErrInvalidInput, err)
+	}
+
+	ethPubKey, err := ethcrypto.DecompressPubkey(pubKeyBytes)
+	if err != nil {
+		return "", service.WrapError(service.ErrInvalidInput, err)
+	}
+	if ethcrypto.PubkeyToAddress(*ethPubKey) != ethcommon.HexToAddress(accountIdentifier.Address) {
+		return "", service.WrapError(service.ErrInvalidInput, errPublicKeyMismatch)
+	}
+
+	pub, err := b.fac.ToPublicKey(pubKeyBytes)
+	if err != nil {
+		return "", service.WrapError(service.ErrInvalidInput, err)
+	}
+
+	hrp, err := mapper.GetHRP(networkIdentifier)
+	if err != nil {
+		return "", service.WrapError(service.ErrInvalidInput, err)
+	}
+
+	addr, err := address.Format(mapper.CChainNetworkIdentifier, hrp, pub.Address().Bytes())
+	if err != nil {
+		return "", service.WrapError(service.ErrInvalidInput, err)
+	}
+
+	if bech32Address, ok := metadata[cmapper.MetadataBech32Address].(string); ok && bech32Address != addr {
+		return "", service.WrapError(service.ErrInvalidInput, errBech32Mismatch)
+	}
+
+	return addr, nil
+}
+
+// fetchCoinsSnapshot fetches the atomic UTXOs of the address together with the current block they are
+// consistent with.
+//
+// The atomic UTXO API can't be queried at a given height, so the block header is read before and after
+// fetching UTXOs. Atomic UTXOs are only consumed on the C-chain by the atomic txs of a block, so the UTXOs
+// are consistent with the header read after fetching them if no block accepted in between carries atomic
+// txs. Otherwise the fetch is retried up to maxUTXOFetchAttempts times, waiting utxoFetchBackoff, doubled
+// after every attempt, in between.
+func (b *Backend) fetchCoinsSnapshot(ctx context.Context, address string) (*ethtypes.Header, []*types.Coin, *types.Error) {
+	backoff := b.utxoFetchBackoff
+	for attempt := 0; attempt < b.maxUTXOFetchAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, nil, service.WrapError(service.ErrInternalError, ctx.Err())
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		preHeader, terr := service.BlockHeaderFromInput(ctx, b.cClient, nil)
+		if terr != nil {
+			return nil, nil, terr
+		}
+
+		coins, terr := b.getAccountCoins(ctx, address)
+		if terr != nil {
+			return nil, nil, terr
+		}
+
+		postHeader, terr := service.BlockHeaderFromInput(ctx, b.cClient, nil)
+		if terr != nil {
+			return nil, nil, terr
+		}
+
+		if preHeader == nil || postHeader == nil {
+			return nil, nil, service.WrapError(service.ErrClientError, "block not found")
+		}
+
+		if postHeader.Hash() == preHeader.Hash() {
+			return postHeader, coins, nil
+		}
+
+		consistent, terr := b.noAtomicTxsAfter(ctx, preHeader, postHeader)
+		if terr != nil {
+			return nil, nil, terr
+		}
+		if consistent {
+			return postHeader, coins, nil
+		}
+	}
+
+	return nil, nil, service.WrapError(service.ErrInternalError, errBlockAddedWhileFetching)
+}
+
+// noAtomicTxsAfter reports whether none of the blocks accepted after preHeader, up to postHeader, carries
+// atomic txs
+func (b *Backend) noAtomicTxsAfter(ctx context.Context, preHeader *ethtypes.Header, postHeader *ethtypes.Header) (bool, *types.Error) {
+	if postHeader.ExtDataHash != ethtypes.EmptyExtDataHash {
+		return false, nil
+	}
+
+	for number := new(big.Int).Add(preHeader.Number, big.NewInt(1)); number.Cmp(postHeader.Number) < 0; number.Add(number, big.NewInt(1)) {
+		header, err := b.cClient.HeaderByNumber(ctx, number)
+		if err != nil {
+			return false, service.WrapError(service.ErrClientError, err)
+		}
+		if header.ExtDataHash != ethtypes.EmptyExtDataHash {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func (b *Backend) getAccountCoins(ctx context.Context, address string) ([]*types.Coin, *types.Error) {
 	var coins []*types.Coin
 	sourceChains := []string{
@@ -124,7 +259,23 @@ func (b *Backend) getAccountCoins(ctx context.Context, address string) ([]*types
 }
 
 func (b *Backend) fetchCoinsFromChain(ctx context.Context, address string, sourceChain string) ([]*types.Coin, *types.Error) {
-	var coins []*types.Coin
+	utxos, wrappedErr := b.fetchUTXOsFromChain(ctx, address, sourceChain)
+	if wrappedErr != nil {
+		return nil, wrappedErr
+	}
+
+	// convert raw UTXO bytes to Rosetta Coins
+	coins, err := b.processUtxos(sourceChain, utxos)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+
+	return coins, nil
+}
+
+// fetchUTXOsFromChain returns the raw atomic UTXOs exported to address from sourceChain
+func (b *Backend) fetchUTXOsFromChain(ctx context.Context, address string, sourceChain string) ([][]byte, *types.Error) {
+	var utxos [][]byte
 
 	// Used for pagination
 	var lastUtxoIndex api.Index
@@ -132,28 +283,22 @@ func (b *Backend) fetchCoinsFromChain(ctx context.Context, address string, sourc
 	for {
 
 		// GetUTXOs controlled by addr
-		utxos, newUtxoIndex, err := b.cClient.GetAtomicUTXOs(ctx, []string{address}, sourceChain, b.getUTXOsPageSize, lastUtxoIndex.Address, lastUtxoIndex.UTXO)
+		utxoPage, newUtxoIndex, err := b.cClient.GetAtomicUTXOs(ctx, []string{address}, sourceChain, b.getUTXOsPageSize, lastUtxoIndex.Address, lastUtxoIndex.UTXO)
 		if err != nil {
 			return nil, service.WrapError(service.ErrInternalError, "unable to get UTXOs")
 		}
 
-		// convert raw UTXO bytes to Rosetta Coins
-		coinsPage, err := b.processUtxos(sourceChain, utxos)
-		if err != nil {
-			return nil, service.WrapError(service.ErrInternalError, err)
-		}
-
-		coins = append(coins, coinsPage...)
+		utxos = append(utxos, utxoPage...)
 
 		// Fetch next page only if there may be more UTXOs
-		if len(utxos) < int(b.getUTXOsPageSize) {
+		if len(utxoPage) < int(b.getUTXOsPageSize) {
 			break
 		}
 
 		lastUtxoIndex = newUtxoIndex
 	}
 
-	return coins, nil
+	return utxos, nil
 }
 
 func (b *Backend) processUtxos(sourceChain string, utxos [][]byte) ([]*types.Coin, error) {
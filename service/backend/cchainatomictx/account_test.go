@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/ava-labs/avalanche-rosetta/mapper"
+	cmapper "github.com/ava-labs/avalanche-rosetta/mapper/cchainatomictx"
 	mocks "github.com/ava-labs/avalanche-rosetta/mocks/client"
 )
 
@@ -34,7 +36,7 @@ var utxos = []utxo{
 
 func TestAccountBalance(t *testing.T) {
 	evmMock := &mocks.Client{}
-	backend := NewBackend(evmMock, ids.Empty)
+	backend := NewBackend(evmMock, ids.Empty, nil)
 	accountAddress := "C-fuji15f9g0h5xkr5cp47n6u3qxj6yjtzzzrdr23a3tl"
 
 	t.Run("C-chain atomic tx balance is sum of UTXOs", func(t *testing.T) {
@@ -68,11 +70,221 @@ func TestAccountBalance(t *testing.T) {
 		assert.Equal(t, mapper.AtomicAvaxCurrency, resp.Balances[0].Currency)
 		assert.Equal(t, "2500000", resp.Balances[0].Value)
 	})
+
+	t.Run("shared memory balance of hex address is mapped with public key", func(t *testing.T) {
+		resp, apiErr := backend.AccountBalance(context.Background(), &types.AccountBalanceRequest{
+			NetworkIdentifier: networkIdentifier,
+			AccountIdentifier: &types.AccountIdentifier{
+				Address: "0xE31ed268b1F74EA12a68cA1677DEE79745B7A105",
+				SubAccount: &types.SubAccountIdentifier{
+					Address: cmapper.SubAccountTypeSharedMemory,
+					Metadata: map[string]interface{}{
+						cmapper.MetadataPublicKey: "02e0d4392cfa224d4be19db416b3cf62e90fb2b7015e7b62a95c8cb490514943f6",
+					},
+				},
+			},
+		})
+		assert.Nil(t, apiErr)
+
+		evmMock.AssertExpectations(t)
+
+		assert.Equal(t, "2500000", resp.Balances[0].Value)
+	})
+
+	t.Run("shared memory balance accepts bech32 address of the public key", func(t *testing.T) {
+		resp, apiErr := backend.AccountBalance(context.Background(), &types.AccountBalanceRequest{
+			NetworkIdentifier: networkIdentifier,
+			AccountIdentifier: &types.AccountIdentifier{
+				Address: "0xE31ed268b1F74EA12a68cA1677DEE79745B7A105",
+				SubAccount: &types.SubAccountIdentifier{
+					Address: cmapper.SubAccountTypeSharedMemory,
+					Metadata: map[string]interface{}{
+						cmapper.MetadataPublicKey:     "02e0d4392cfa224d4be19db416b3cf62e90fb2b7015e7b62a95c8cb490514943f6",
+						cmapper.MetadataBech32Address: accountAddress,
+					},
+				},
+			},
+		})
+		assert.Nil(t, apiErr)
+		assert.Equal(t, "2500000", resp.Balances[0].Value)
+	})
+
+	t.Run("shared memory balance rejects public key of another address", func(t *testing.T) {
+		resp, apiErr := backend.AccountBalance(context.Background(), &types.AccountBalanceRequest{
+			NetworkIdentifier: networkIdentifier,
+			AccountIdentifier: &types.AccountIdentifier{
+				Address: cAccountIdentifier.Address,
+				SubAccount: &types.SubAccountIdentifier{
+					Address: cmapper.SubAccountTypeSharedMemory,
+					Metadata: map[string]interface{}{
+						cmapper.MetadataPublicKey: "02e0d4392cfa224d4be19db416b3cf62e90fb2b7015e7b62a95c8cb490514943f6",
+					},
+				},
+			},
+		})
+		assert.Nil(t, resp)
+		assert.Equal(t, errPublicKeyMismatch.Error(), apiErr.Details["error"])
+	})
+
+	t.Run("shared memory balance requires a public key", func(t *testing.T) {
+		resp, apiErr := backend.AccountBalance(context.Background(), &types.AccountBalanceRequest{
+			NetworkIdentifier: networkIdentifier,
+			AccountIdentifier: &types.AccountIdentifier{
+				Address: cAccountIdentifier.Address,
+				SubAccount: &types.SubAccountIdentifier{
+					Address: cmapper.SubAccountTypeSharedMemory,
+					Metadata: map[string]interface{}{
+						cmapper.MetadataBech32Address: accountAddress,
+					},
+				},
+			},
+		})
+		assert.Nil(t, resp)
+		assert.Equal(t, errNoPublicKey.Error(), apiErr.Details["error"])
+	})
+
+	t.Run("shared memory balance rejects bech32 address of another key", func(t *testing.T) {
+		resp, apiErr := backend.AccountBalance(context.Background(), &types.AccountBalanceRequest{
+			NetworkIdentifier: networkIdentifier,
+			AccountIdentifier: &types.AccountIdentifier{
+				Address: "0xE31ed268b1F74EA12a68cA1677DEE79745B7A105",
+				SubAccount: &types.SubAccountIdentifier{
+					Address: cmapper.SubAccountTypeSharedMemory,
+					Metadata: map[string]interface{}{
+						cmapper.MetadataPublicKey:     "02e0d4392cfa224d4be19db416b3cf62e90fb2b7015e7b62a95c8cb490514943f6",
+						cmapper.MetadataBech32Address: "C-fuji1gdkq8g208e3j4epyjmx65jglsw7vauh86l47ac",
+					},
+				},
+			},
+		})
+		assert.Nil(t, resp)
+		assert.Equal(t, errBech32Mismatch.Error(), apiErr.Details["error"])
+	})
+}
+
+func TestAccountBalanceRetries(t *testing.T) {
+	accountAddress := "C-fuji15f9g0h5xkr5cp47n6u3qxj6yjtzzzrdr23a3tl"
+	header42 := &ethtypes.Header{Number: big.NewInt(42)}
+	header43 := &ethtypes.Header{Number: big.NewInt(43)}
+	header44 := &ethtypes.Header{Number: big.NewInt(44)}
+	req := &types.AccountBalanceRequest{
+		NetworkIdentifier: &types.NetworkIdentifier{},
+		AccountIdentifier: &types.AccountIdentifier{
+			Address: accountAddress,
+		},
+	}
+
+	t.Run("balance is read again if a block is added while fetching utxos", func(t *testing.T) {
+		evmMock := &mocks.Client{}
+		backend := NewBackend(evmMock, ids.Empty, nil)
+		backend.utxoFetchBackoff = time.Millisecond
+		utxoBytes := makeUtxoBytes(t, backend, utxos[0].id, utxos[0].amount)
+
+		evmMock.
+			On("GetAtomicUTXOs", mock.Anything, []string{accountAddress}, "P", backend.getUTXOsPageSize, "", "").
+			Return([][]byte{utxoBytes}, api.Index{}, nil).Twice()
+		evmMock.
+			On("GetAtomicUTXOs", mock.Anything, []string{accountAddress}, "X", backend.getUTXOsPageSize, "", "").
+			Return([][]byte{}, api.Index{}, nil).Twice()
+		evmMock.On("HeaderByNumber", mock.Anything, mock.Anything).Return(header42, nil).Once()
+		evmMock.On("HeaderByNumber", mock.Anything, mock.Anything).Return(header43, nil).Times(3)
+
+		resp, apiErr := backend.AccountBalance(context.Background(), req)
+		assert.Nil(t, apiErr)
+		evmMock.AssertExpectations(t)
+
+		assert.Equal(t, int64(43), resp.BlockIdentifier.Index)
+		assert.Equal(t, header43.Hash().String(), resp.BlockIdentifier.Hash)
+		assert.Equal(t, "1000000", resp.Balances[0].Value)
+	})
+
+	t.Run("balance is not read again if blocks added while fetching utxos carry no atomic txs", func(t *testing.T) {
+		evmMock := &mocks.Client{}
+		backend := NewBackend(evmMock, ids.Empty, nil)
+		utxoBytes := makeUtxoBytes(t, backend, utxos[0].id, utxos[0].amount)
+		header44 := &ethtypes.Header{Number: big.NewInt(44), ExtDataHash: ethtypes.EmptyExtDataHash}
+		header43 := &ethtypes.Header{Number: big.NewInt(43), ExtDataHash: ethtypes.EmptyExtDataHash}
+
+		evmMock.
+			On("GetAtomicUTXOs", mock.Anything, []string{accountAddress}, "P", backend.getUTXOsPageSize, "", "").
+			Return([][]byte{utxoBytes}, api.Index{}, nil).Once()
+		evmMock.
+			On("GetAtomicUTXOs", mock.Anything, []string{accountAddress}, "X", backend.getUTXOsPageSize, "", "").
+			Return([][]byte{}, api.Index{}, nil).Once()
+		evmMock.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header42, nil).Once()
+		evmMock.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header44, nil).Once()
+		evmMock.On("HeaderByNumber", mock.Anything, big.NewInt(43)).Return(header43, nil).Once()
+
+		resp, apiErr := backend.AccountBalance(context.Background(), req)
+		assert.Nil(t, apiErr)
+		evmMock.AssertExpectations(t)
+
+		assert.Equal(t, int64(44), resp.BlockIdentifier.Index)
+		assert.Equal(t, "1000000", resp.Balances[0].Value)
+	})
+
+	t.Run("balance fails after max fetch attempts", func(t *testing.T) {
+		evmMock := &mocks.Client{}
+		backend := NewBackend(evmMock, ids.Empty, nil)
+		backend.maxUTXOFetchAttempts = 2
+		backend.utxoFetchBackoff = time.Millisecond
+
+		evmMock.
+			On("GetAtomicUTXOs", mock.Anything, []string{accountAddress}, mock.Anything, backend.getUTXOsPageSize, "", "").
+			Return([][]byte{}, api.Index{}, nil)
+		evmMock.On("HeaderByNumber", mock.Anything, mock.Anything).Return(header42, nil).Once()
+		evmMock.On("HeaderByNumber", mock.Anything, mock.Anything).Return(header43, nil).Twice()
+		evmMock.On("HeaderByNumber", mock.Anything, mock.Anything).Return(header44, nil).Once()
+
+		resp, apiErr := backend.AccountBalance(context.Background(), req)
+		assert.Nil(t, resp)
+		assert.Equal(t, errBlockAddedWhileFetching.Error(), apiErr.Details["error"])
+		evmMock.AssertExpectations(t)
+	})
+}
+
+func TestAccountBalanceAtBlock(t *testing.T) {
+	accountAddress := "C-fuji15f9g0h5xkr5cp47n6u3qxj6yjtzzzrdr23a3tl"
+	header := &ethtypes.Header{Number: big.NewInt(42)}
+	evmMock := &mocks.Client{}
+	backend := NewBackend(evmMock, ids.Empty, nil)
+
+	evmMock.
+		On("GetAtomicUTXOs", mock.Anything, []string{accountAddress}, mock.Anything, backend.getUTXOsPageSize, "", "").
+		Return([][]byte{}, api.Index{}, nil)
+	evmMock.On("HeaderByNumber", mock.Anything, mock.Anything).Return(header, nil)
+
+	balanceAt := func(blockIdentifier *types.PartialBlockIdentifier) (*types.AccountBalanceResponse, *types.Error) {
+		return backend.AccountBalance(context.Background(), &types.AccountBalanceRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{},
+			AccountIdentifier: &types.AccountIdentifier{Address: accountAddress},
+			BlockIdentifier:   blockIdentifier,
+		})
+	}
+
+	t.Run("current block is accepted", func(t *testing.T) {
+		resp, apiErr := balanceAt(&types.PartialBlockIdentifier{
+			Index: types.Int64(42),
+			Hash:  types.String(header.Hash().String()),
+		})
+		assert.Nil(t, apiErr)
+		assert.Equal(t, int64(42), resp.BlockIdentifier.Index)
+	})
+
+	t.Run("past block is rejected", func(t *testing.T) {
+		resp, apiErr := balanceAt(&types.PartialBlockIdentifier{Index: types.Int64(41)})
+		assert.Nil(t, resp)
+		assert.Equal(t, errHistoricalBalance.Error(), apiErr.Details["error"])
+
+		resp, apiErr = balanceAt(&types.PartialBlockIdentifier{Hash: types.String("0x00")})
+		assert.Nil(t, resp)
+		assert.Equal(t, errHistoricalBalance.Error(), apiErr.Details["error"])
+	})
 }
 
 func TestAccountCoins(t *testing.T) {
 	evmMock := &mocks.Client{}
-	backend := NewBackend(evmMock, ids.Empty)
+	backend := NewBackend(evmMock, ids.Empty, nil)
 	// changing page size to 2 to test pagination as well
 	backend.getUTXOsPageSize = 2
 	accountAddress := "C-fuji15f9g0h5xkr5cp47n6u3qxj6yjtzzzrdr23a3tl"
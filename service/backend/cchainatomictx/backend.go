@@ -1,6 +1,8 @@
 package cchainatomictx
 
 import (
+	"time"
+
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto"
@@ -11,37 +13,50 @@ import (
 	"github.com/ava-labs/avalanche-rosetta/mapper"
 	cmapper "github.com/ava-labs/avalanche-rosetta/mapper/cchainatomictx"
 	"github.com/ava-labs/avalanche-rosetta/service"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/common"
 )
 
+// CallMethods are the call methods answered by the C-chain atomic tx backend
+var CallMethods = []string{mapper.CallSelectUTXOs}
+
 type Backend struct {
 	service.AccountBackend
 	service.ConstructionBackend
 
-	fac              *crypto.FactorySECP256K1R
-	cClient          client.Client
-	getUTXOsPageSize uint32
-	codec            codec.Manager
-	codecVersion     uint16
-	avaxAssetID      ids.ID
+	fac                  *crypto.FactorySECP256K1R
+	cClient              client.Client
+	getUTXOsPageSize     uint32
+	maxUTXOFetchAttempts int
+	utxoFetchBackoff     time.Duration
+	codec                codec.Manager
+	codecVersion         uint16
+	avaxAssetID          ids.ID
+	mempool              *common.Mempool
 }
 
-func NewBackend(cClient client.Client, avaxAssetId ids.ID) *Backend {
-	return &Backend{
-		fac:              &crypto.FactorySECP256K1R{},
-		cClient:          cClient,
-		avaxAssetID:      avaxAssetId,
-		getUTXOsPageSize: 1024,
-		codec:            evm.Codec,
-		codecVersion:     0,
+// NewBackend returns a C-chain atomic tx backend. Submitted txs stay in the
+// mempool until submissions reports them as decided.
+func NewBackend(cClient client.Client, avaxAssetId ids.ID, submissions common.SubmissionStatuses) *Backend {
+	b := &Backend{
+		fac:                  &crypto.FactorySECP256K1R{},
+		cClient:              cClient,
+		avaxAssetID:          avaxAssetId,
+		getUTXOsPageSize:     1024,
+		maxUTXOFetchAttempts: 3,
+		utxoFetchBackoff:     100 * time.Millisecond,
+		codec:                evm.Codec,
+		codecVersion:         0,
 	}
+	b.mempool = common.NewMempool(submissions)
+	return b
 }
 
 func (b *Backend) ShouldHandleRequest(req interface{}) bool {
 	switch r := req.(type) {
 	case *types.AccountBalanceRequest:
-		return cmapper.IsCChainBech32Address(r.AccountIdentifier)
+		return cmapper.IsCChainBech32Address(r.AccountIdentifier) || cmapper.IsSharedMemorySubAccount(r.AccountIdentifier)
 	case *types.AccountCoinsRequest:
-		return cmapper.IsCChainBech32Address(r.AccountIdentifier)
+		return cmapper.IsCChainBech32Address(r.AccountIdentifier) || cmapper.IsSharedMemorySubAccount(r.AccountIdentifier)
 	case *types.ConstructionDeriveRequest:
 		return r.Metadata[mapper.MetaAddressFormat] == mapper.AddressFormatBech32
 	case *types.ConstructionMetadataRequest:
@@ -58,6 +73,10 @@ func (b *Backend) ShouldHandleRequest(req interface{}) bool {
 		return b.isCchainAtomicTx(r.SignedTransaction)
 	case *types.ConstructionSubmitRequest:
 		return b.isCchainAtomicTx(r.SignedTransaction)
+	case *types.MempoolTransactionRequest:
+		return b.mempool.Has(r.TransactionIdentifier.Hash)
+	case *types.CallRequest:
+		return r.Method == mapper.CallSelectUTXOs
 	}
 
 	return false
@@ -0,0 +1,75 @@
+package cchainatomictx
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	"github.com/ava-labs/avalanche-rosetta/service"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/common"
+)
+
+// Call implements /call endpoint for C-chain atomic txs
+func (b *Backend) Call(ctx context.Context, req *types.CallRequest) (*types.CallResponse, *types.Error) {
+	switch req.Method {
+	case mapper.CallSelectUTXOs:
+		return b.callSelectUTXOs(ctx, req)
+	default:
+		return nil, service.ErrCallInvalidMethod
+	}
+}
+
+// callSelectUTXOs selects the atomic UTXOs exported to a bech32 C-chain
+// address covering the requested amount and returns the import operations
+// spending them. The change is left to the EVM output of the import tx.
+func (b *Backend) callSelectUTXOs(ctx context.Context, req *types.CallRequest) (*types.CallResponse, *types.Error) {
+	input, target, tErr := common.ParseSelectUTXOsInput(req)
+	if tErr != nil {
+		return nil, tErr
+	}
+	if len(input.SourceChain) == 0 {
+		return nil, service.WrapError(service.ErrCallInvalidParams, "source_chain missing from params")
+	}
+
+	utxoBytes, tErr := b.fetchUTXOsFromChain(ctx, input.Address, input.SourceChain)
+	if tErr != nil {
+		return nil, tErr
+	}
+
+	utxos := make([]avax.UTXO, 0, len(utxoBytes))
+	for _, bytes := range utxoBytes {
+		utxo := avax.UTXO{}
+		if _, err := platformvm.Codec.Unmarshal(bytes, &utxo); err != nil {
+			return nil, service.WrapError(service.ErrInternalError, errUnableToParseUTXO)
+		}
+		utxos = append(utxos, utxo)
+	}
+
+	selected, total, err := common.SelectUTXOs(utxos, b.avaxAssetID, target, uint64(time.Now().Unix()))
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+
+	account := &types.AccountIdentifier{Address: input.Address}
+	operations := make([]*types.Operation, 0, len(selected))
+	for i, utxo := range selected {
+		amount := new(big.Int).SetUint64(utxo.Amount)
+		operations = append(operations, &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{Index: int64(i)},
+			Type:                mapper.OpImport,
+			Account:             account,
+			Amount:              mapper.AtomicAvaxAmount(amount.Neg(amount)),
+			CoinChange: &types.CoinChange{
+				CoinIdentifier: &types.CoinIdentifier{Identifier: utxo.ID},
+				CoinAction:     types.CoinSpent,
+			},
+		})
+	}
+
+	return common.SelectUTXOsResponse(operations, total, target)
+}
@@ -324,7 +324,13 @@ func (b *Backend) ConstructionSubmit(
 		return nil, service.WrapError(service.ErrInvalidInput, err)
 	}
 
-	return common.SubmitTx(ctx, b.cClient, rosettaTx)
+	resp, terr := common.SubmitTx(ctx, b.cClient, rosettaTx)
+	if terr != nil {
+		return nil, terr
+	}
+
+	b.mempool.TrackSubmittedTx(ctx, b, req, resp.TransactionIdentifier)
+	return resp, nil
 }
 
 func (b *Backend) parsePayloadTxFromString(transaction string) (*common.RosettaTx, error) {
@@ -50,7 +50,7 @@ func buildRosettaSignerJson(coinIdentifiers []string, signers []*types.AccountId
 }
 
 func TestConstructionDerive(t *testing.T) {
-	backend := NewBackend(&mocks.Client{}, ids.Empty)
+	backend := NewBackend(&mocks.Client{}, ids.Empty, nil)
 
 	t.Run("c-chain address", func(t *testing.T) {
 		src := "02e0d4392cfa224d4be19db416b3cf62e90fb2b7015e7b62a95c8cb490514943f6"
@@ -153,7 +153,7 @@ func TestExportTxConstruction(t *testing.T) {
 
 	ctx := context.Background()
 	clientMock := &mocks.Client{}
-	backend := NewBackend(clientMock, avaxAssetID)
+	backend := NewBackend(clientMock, avaxAssetID, nil)
 
 	t.Run("preprocess endpoint", func(t *testing.T) {
 		req := &types.ConstructionPreprocessRequest{
@@ -384,7 +384,7 @@ func TestImportTxConstruction(t *testing.T) {
 
 	ctx := context.Background()
 	clientMock := &mocks.Client{}
-	backend := NewBackend(clientMock, avaxAssetID)
+	backend := NewBackend(clientMock, avaxAssetID, nil)
 
 	t.Run("preprocess endpoint", func(t *testing.T) {
 		req := &types.ConstructionPreprocessRequest{
@@ -0,0 +1,20 @@
+package cchainatomictx
+
+import (
+	"context"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// Mempool implements the /mempool endpoint for atomic txs
+func (b *Backend) Mempool(ctx context.Context, req *types.NetworkRequest) (*types.MempoolResponse, *types.Error) {
+	return b.mempool.Mempool(ctx, req)
+}
+
+// MempoolTransaction implements the /mempool/transaction endpoint for atomic txs
+func (b *Backend) MempoolTransaction(
+	ctx context.Context,
+	req *types.MempoolTransactionRequest,
+) (*types.MempoolTransactionResponse, *types.Error) {
+	return b.mempool.MempoolTransaction(ctx, req)
+}
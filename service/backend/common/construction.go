@@ -1,9 +1,11 @@
 package common
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto"
@@ -14,6 +16,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/coinbase/rosetta-sdk-go/parser"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/ava-labs/avalanche-rosetta/mapper"
 	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
@@ -26,6 +30,9 @@ var (
 	errInvalidInputSignatureLen = errors.New("input signature length doesn't match credentials needed")
 	errInsufficientSignatures   = errors.New("insufficient signatures")
 	errInvalidSignatureLen      = errors.New("invalid signature length")
+	errMissingSigningPayload    = errors.New("signature is missing its signing payload account")
+	errSigningPayloadMismatch   = errors.New("signing payload does not match the transaction")
+	errUnexpectedSigner         = errors.New("account is not a signer of the transaction")
 )
 
 func DeriveBech32Address(
@@ -33,14 +40,36 @@ func DeriveBech32Address(
 	chainIdAlias string,
 	req *types.ConstructionDeriveRequest,
 ) (*types.ConstructionDeriveResponse, *types.Error) {
-	pub, err := fac.ToPublicKey(req.PublicKey.Bytes)
+	key, err := mapper.ParsePublicKey(req.PublicKey)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+
+	if req.Metadata[mapper.MetaAddressFormat] == mapper.AddressFormatAll {
+		addresses, err := mapper.DeriveAddresses(req.NetworkIdentifier, key)
+		if err != nil {
+			return nil, service.WrapError(service.ErrInvalidInput, err)
+		}
+
+		return &types.ConstructionDeriveResponse{
+			AccountIdentifier: &types.AccountIdentifier{
+				Address: addresses[chainIdAlias],
+			},
+			Metadata: map[string]interface{}{
+				mapper.MetaAddressFormat: mapper.AddressFormatAll,
+				mapper.MetaAddresses:     addresses,
+			},
+		}, nil
+	}
+
+	pub, err := fac.ToPublicKey(ethcrypto.CompressPubkey(key))
 	if err != nil {
 		return nil, service.WrapError(service.ErrInvalidInput, err)
 	}
 
 	hrp, getErr := mapper.GetHRP(req.NetworkIdentifier)
 	if getErr != nil {
-		return nil, service.WrapError(service.ErrInvalidInput, err)
+		return nil, service.WrapError(service.ErrInvalidInput, getErr)
 	}
 
 	addr, err := address.Format(chainIdAlias, hrp, pub.Address().Bytes())
@@ -234,9 +263,19 @@ func BuildPayloads(
 			coinIdentifier = o.CoinChange.CoinIdentifier.Identifier
 		}
 
+		var owners []string
+		if _, ok := o.Metadata[pmapper.MetadataOwners]; ok {
+			opMetadata, err := pmapper.ParseOpMetadata(o.Metadata)
+			if err != nil {
+				return nil, service.WrapError(service.ErrInvalidInput, err)
+			}
+			owners = opMetadata.Owners
+		}
+
 		accountIdentifierSigners = append(accountIdentifierSigners, Signer{
 			CoinIdentifier:    coinIdentifier,
 			AccountIdentifier: o.Account,
+			Owners:            owners,
 		})
 	}
 
@@ -330,6 +369,10 @@ func Combine(
 	rosettaTx *RosettaTx,
 	signatures []*types.Signature,
 ) (*types.ConstructionCombineResponse, *types.Error) {
+	if tErr := VerifySignatures(rosettaTx, signatures); tErr != nil {
+		return nil, tErr
+	}
+
 	combinedTx, tErr := combiner.CombineTx(rosettaTx.Tx, signatures)
 	if tErr != nil {
 		return nil, tErr
@@ -349,3 +392,82 @@ func Combine(
 		SignedTransaction: string(signedTransaction),
 	}, nil
 }
+
+// VerifySignatures checks that every signature is made over the signing
+// payload of rosettaTx by the account of its payload, and that this account
+// is one of the signers of rosettaTx, so that a bad signature is rejected
+// before the transaction is broadcast
+func VerifySignatures(rosettaTx *RosettaTx, signatures []*types.Signature) *types.Error {
+	hash, err := rosettaTx.Tx.SigningPayload()
+	if err != nil {
+		return service.WrapError(service.ErrInvalidInput, err)
+	}
+
+	expected := map[string]bool{}
+	for _, signer := range rosettaTx.AccountIdentifierSigners {
+		if signer.AccountIdentifier != nil {
+			expected[signerKey(signer.AccountIdentifier.Address)] = true
+		}
+		for _, owner := range signer.Owners {
+			expected[signerKey(owner)] = true
+		}
+	}
+
+	fac := crypto.FactorySECP256K1R{}
+	for i, signature := range signatures {
+		payload := signature.SigningPayload
+		if payload == nil || payload.AccountIdentifier == nil {
+			return service.WrapError(service.ErrInvalidSignature, fmt.Errorf("signature %d: %w", i, errMissingSigningPayload))
+		}
+		if !expected[signerKey(payload.AccountIdentifier.Address)] {
+			return service.WrapError(service.ErrInvalidSignature,
+				fmt.Errorf("signature %d: %s: %w", i, payload.AccountIdentifier.Address, errUnexpectedSigner))
+		}
+		if !bytes.Equal(payload.Bytes, hash) {
+			return service.WrapError(service.ErrInvalidSignature, fmt.Errorf("signature %d: %w", i, errSigningPayloadMismatch))
+		}
+
+		pub, err := fac.RecoverHashPublicKey(hash, signature.Bytes)
+		if err != nil {
+			return service.WrapError(service.ErrInvalidSignature, fmt.Errorf("signature %d: %w", i, err))
+		}
+		if !isSigner(pub, payload.AccountIdentifier.Address) {
+			return service.WrapError(service.ErrInvalidSignature,
+				fmt.Errorf("signature %d is not made by %s", i, payload.AccountIdentifier.Address))
+		}
+	}
+
+	return nil
+}
+
+// signerKey identifies the key behind account regardless of the chain its
+// bech32 address is formatted for
+func signerKey(account string) string {
+	if ethcommon.IsHexAddress(account) {
+		return ethcommon.HexToAddress(account).Hex()
+	}
+
+	addr, err := address.ParseToID(account)
+	if err != nil {
+		return account
+	}
+	return addr.String()
+}
+
+// isSigner returns true if pub is the key of account, either a bech32 address
+// or the hex address of an EVM input
+func isSigner(pub crypto.PublicKey, account string) bool {
+	if ethcommon.IsHexAddress(account) {
+		secpPub, ok := pub.(*crypto.PublicKeySECP256K1R)
+		if !ok {
+			return false
+		}
+		return ethcrypto.PubkeyToAddress(*secpPub.ToECDSA()) == ethcommon.HexToAddress(account)
+	}
+
+	addr, err := address.ParseToID(account)
+	if err != nil {
+		return false
+	}
+	return addr == pub.Address()
+}
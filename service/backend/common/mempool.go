@@ -0,0 +1,162 @@
+package common
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+// SubmissionStatuses reports the statuses of submitted transactions, as
+// polled in the background by the submission tracker
+type SubmissionStatuses interface {
+	// IsPending returns whether the tx with the given hash is neither decided
+	// nor dropped yet, and whether it is tracked at all
+	IsPending(hash string) (pending bool, tracked bool)
+}
+
+// ConstructionParser parses the transactions of a backend
+type ConstructionParser interface {
+	ConstructionParse(
+		ctx context.Context,
+		req *types.ConstructionParseRequest,
+	) (*types.ConstructionParseResponse, *types.Error)
+}
+
+// Mempool keeps the transactions submitted through a backend until the
+// submission tracker reports them as decided. Statuses are never fetched
+// from the node while serving requests.
+type Mempool struct {
+	lock     sync.Mutex
+	txs      map[ids.ID]*types.Transaction
+	statuses SubmissionStatuses
+}
+
+// NewMempool returns an empty mempool that uses statuses to evict decided txs
+func NewMempool(statuses SubmissionStatuses) *Mempool {
+	return &Mempool{
+		txs:      map[ids.ID]*types.Transaction{},
+		statuses: statuses,
+	}
+}
+
+// Add starts tracking tx under txID
+func (m *Mempool) Add(txID ids.ID, tx *types.Transaction) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.txs[txID] = tx
+}
+
+// TrackSubmittedTx adds a tx submitted through /construction/submit along with
+// the operations parser finds in it
+func (m *Mempool) TrackSubmittedTx(
+	ctx context.Context,
+	parser ConstructionParser,
+	req *types.ConstructionSubmitRequest,
+	txIdentifier *types.TransactionIdentifier,
+) {
+	txID, err := ids.FromString(txIdentifier.Hash)
+	if err != nil {
+		return
+	}
+
+	tx := &types.Transaction{TransactionIdentifier: txIdentifier}
+	parsed, terr := parser.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: req.NetworkIdentifier,
+		Signed:            true,
+		Transaction:       req.SignedTransaction,
+	})
+	if terr == nil {
+		tx.Operations = parsed.Operations
+	}
+
+	m.Add(txID, tx)
+}
+
+// Remove stops tracking the given txs, typically once they are seen in a block
+func (m *Mempool) Remove(txIDs ...ids.ID) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, txID := range txIDs {
+		delete(m.txs, txID)
+	}
+}
+
+// Has returns true if hash identifies a tracked tx
+func (m *Mempool) Has(hash string) bool {
+	txID, err := ids.FromString(hash)
+	if err != nil {
+		return false
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	_, ok := m.txs[txID]
+	return ok
+}
+
+// Mempool implements the /mempool endpoint
+func (m *Mempool) Mempool(ctx context.Context, req *types.NetworkRequest) (*types.MempoolResponse, *types.Error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	identifiers := []*types.TransactionIdentifier{}
+	for txID := range m.txs {
+		if m.isPending(txID) {
+			identifiers = append(identifiers, &types.TransactionIdentifier{Hash: txID.String()})
+		}
+	}
+	sort.Slice(identifiers, func(i, j int) bool {
+		return identifiers[i].Hash < identifiers[j].Hash
+	})
+
+	return &types.MempoolResponse{
+		TransactionIdentifiers: identifiers,
+	}, nil
+}
+
+// MempoolTransaction implements the /mempool/transaction endpoint
+func (m *Mempool) MempoolTransaction(
+	ctx context.Context,
+	req *types.MempoolTransactionRequest,
+) (*types.MempoolTransactionResponse, *types.Error) {
+	txID, err := ids.FromString(req.TransactionIdentifier.Hash)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	tx, ok := m.txs[txID]
+	if !ok || !m.isPending(txID) {
+		return nil, service.ErrTransactionNotFound
+	}
+
+	return &types.MempoolTransactionResponse{
+		Transaction: tx,
+	}, nil
+}
+
+// isPending returns false and evicts txID once its submission is decided.
+// Txs the tracker does not know yet are kept. It must be called with the
+// lock held.
+func (m *Mempool) isPending(txID ids.ID) bool {
+	if m.statuses == nil {
+		return true
+	}
+
+	pending, tracked := m.statuses.IsPending(txID.String())
+	if tracked && !pending {
+		delete(m.txs, txID)
+		return false
+	}
+	return true
+}
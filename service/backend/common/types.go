@@ -8,6 +8,7 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/types"
 
 	"github.com/ava-labs/avalanche-rosetta/mapper"
+	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
 )
 
 type AvaxTx interface {
@@ -32,6 +33,8 @@ type RosettaTx struct {
 type Signer struct {
 	CoinIdentifier    string                   `json:"coin_identifier,omitempty"`
 	AccountIdentifier *types.AccountIdentifier `json:"account_identifier"`
+	// Owners is only set for inputs spending multisig UTXOs
+	Owners []string `json:"owners,omitempty"`
 }
 
 type rosettaTxWire struct {
@@ -92,8 +95,12 @@ func (t *RosettaTx) GetAccountIdentifiers(operations []*types.Operation) ([]*typ
 	var signers []*types.AccountIdentifier
 
 	operationToAccountMap := make(map[string]*types.AccountIdentifier)
+	operationToOwnersMap := make(map[string][]string)
 	for _, data := range t.AccountIdentifierSigners {
 		operationToAccountMap[data.CoinIdentifier] = data.AccountIdentifier
+		if len(data.Owners) > 0 {
+			operationToOwnersMap[data.CoinIdentifier] = data.Owners
+		}
 	}
 
 	for _, op := range operations {
@@ -112,6 +119,20 @@ func (t *RosettaTx) GetAccountIdentifiers(operations []*types.Operation) ([]*typ
 			return nil, errors.New("not all operations have signers")
 		}
 
+		// multisig inputs are signed by the owners referenced by their signature indices
+		if owners, ok := operationToOwnersMap[coinIdentifier]; ok {
+			opMetadata, err := pmapper.ParseOpMetadata(op.Metadata)
+			if err != nil {
+				return nil, err
+			}
+			msigSigners, err := pmapper.MultisigSigners(owners, opMetadata.SigIndices)
+			if err != nil {
+				return nil, err
+			}
+			signers = append(signers, msigSigners...)
+			continue
+		}
+
 		signers = append(signers, signer)
 	}
 
@@ -0,0 +1,151 @@
+package common
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+var (
+	errInsufficientFunds = errors.New("insufficient spendable funds")
+	errZeroTarget        = errors.New("amount must be positive")
+)
+
+// SelectUTXOsInput is the input to the call method mapper.CallSelectUTXOs.
+// Amount is in nAVAX. On the P-chain, the fee of OperationType is added to it,
+// while on the C-chain the import fee is paid from the imported amount. If
+// SourceChain is set, the UTXOs exported to Address from that chain are
+// selected for an import.
+type SelectUTXOsInput struct {
+	Address       string `json:"address"`
+	Amount        string `json:"amount"`
+	OperationType string `json:"operation_type,omitempty"`
+	SourceChain   string `json:"source_chain,omitempty"`
+}
+
+// SelectUTXOsResult is the result of the call method mapper.CallSelectUTXOs.
+// Operations are the input operations spending the selected UTXOs, followed
+// by the change operation if the chain supports one.
+type SelectUTXOsResult struct {
+	Operations []*types.Operation `json:"operations"`
+	Total      string             `json:"total"`
+	Change     string             `json:"change"`
+}
+
+// ParseSelectUTXOsInput parses the parameters of a mapper.CallSelectUTXOs
+// call and returns them along with the amount to cover
+func ParseSelectUTXOsInput(req *types.CallRequest) (*SelectUTXOsInput, uint64, *types.Error) {
+	var input SelectUTXOsInput
+	if err := types.UnmarshalMap(req.Parameters, &input); err != nil {
+		return nil, 0, service.WrapError(service.ErrCallInvalidParams, err)
+	}
+	if len(input.Address) == 0 {
+		return nil, 0, service.WrapError(service.ErrCallInvalidParams, "address missing from params")
+	}
+
+	amount, err := strconv.ParseUint(input.Amount, 10, 64)
+	if err != nil {
+		return nil, 0, service.WrapError(service.ErrCallInvalidParams, err)
+	}
+
+	return &input, amount, nil
+}
+
+// SelectUTXOsResponse wraps the result of a mapper.CallSelectUTXOs call
+func SelectUTXOsResponse(operations []*types.Operation, total uint64, target uint64) (*types.CallResponse, *types.Error) {
+	result, err := mapper.MarshalJSONMap(&SelectUTXOsResult{
+		Operations: operations,
+		Total:      strconv.FormatUint(total, 10),
+		Change:     strconv.FormatUint(total-target, 10),
+	})
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+
+	return &types.CallResponse{Result: result}, nil
+}
+
+// SelectedUTXO is a UTXO picked by SelectUTXOs
+type SelectedUTXO struct {
+	ID     string
+	Amount uint64
+}
+
+// SpendableAmount returns the amount of utxo if it can be spent at time now
+// by its single owner with a plain secp256k1 transfer input. Multisig UTXOs
+// and UTXOs that are still time locked or stakeable locked are not spendable.
+func SpendableAmount(utxo *avax.UTXO, now uint64) (uint64, bool) {
+	out := utxo.Out
+	if lockedOut, ok := out.(*stakeable.LockOut); ok {
+		if lockedOut.Locktime > now {
+			return 0, false
+		}
+		out = lockedOut.TransferableOut
+	}
+
+	transferOut, ok := out.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return 0, false
+	}
+	if transferOut.Locktime > now || transferOut.Threshold != 1 || len(transferOut.Addrs) != 1 {
+		return 0, false
+	}
+	return transferOut.Amount(), true
+}
+
+// SelectUTXOs picks spendable UTXOs of assetID, largest first, until their
+// total covers target. It returns the selected UTXOs and their total.
+func SelectUTXOs(utxos []avax.UTXO, assetID ids.ID, target uint64, now uint64) ([]*SelectedUTXO, uint64, error) {
+	if target == 0 {
+		return nil, 0, errZeroTarget
+	}
+
+	candidates := []*SelectedUTXO{}
+	for i := range utxos {
+		utxo := &utxos[i]
+		if utxo.AssetID() != assetID {
+			continue
+		}
+		amount, ok := SpendableAmount(utxo, now)
+		if !ok || amount == 0 {
+			continue
+		}
+		candidates = append(candidates, &SelectedUTXO{
+			ID:     utxo.UTXOID.String(),
+			Amount: amount,
+		})
+	}
+
+	// Largest first keeps the number of inputs, and so the number of
+	// signatures, as low as possible
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Amount != candidates[j].Amount {
+			return candidates[i].Amount > candidates[j].Amount
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	var total uint64
+	for i, candidate := range candidates {
+		newTotal, err := math.Add64(total, candidate.Amount)
+		if err != nil {
+			return nil, 0, err
+		}
+		total = newTotal
+		if total >= target {
+			return candidates[:i+1], total, nil
+		}
+	}
+
+	return nil, 0, errInsufficientFunds
+}
@@ -0,0 +1,86 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/assert"
+)
+
+const now = uint64(1_000)
+
+var (
+	assetID = ids.GenerateTestID()
+	owner   = ids.GenerateTestShortID()
+)
+
+func makeUTXO(outputIndex uint32, asset ids.ID, out avax.TransferableOut) avax.UTXO {
+	return avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.Empty.Prefix(uint64(outputIndex)), OutputIndex: outputIndex},
+		Asset:  avax.Asset{ID: asset},
+		Out:    out,
+	}
+}
+
+func makeOut(amount uint64, locktime uint64, owners ...ids.ShortID) *secp256k1fx.TransferOutput {
+	if len(owners) == 0 {
+		owners = []ids.ShortID{owner}
+	}
+	return &secp256k1fx.TransferOutput{
+		Amt: amount,
+		OutputOwners: secp256k1fx.OutputOwners{
+			Locktime:  locktime,
+			Threshold: 1,
+			Addrs:     owners,
+		},
+	}
+}
+
+func TestSelectUTXOs(t *testing.T) {
+	utxos := []avax.UTXO{
+		makeUTXO(0, assetID, makeOut(100, 0)),
+		makeUTXO(1, assetID, makeOut(300, 0)),
+		makeUTXO(2, assetID, makeOut(200, 0)),
+		// time locked
+		makeUTXO(3, assetID, makeOut(1_000, now+1)),
+		// stakeable locked
+		makeUTXO(4, assetID, &stakeable.LockOut{Locktime: now + 1, TransferableOut: makeOut(1_000, 0)}),
+		// multisig
+		makeUTXO(5, assetID, makeOut(1_000, 0, owner, ids.GenerateTestShortID())),
+		// other asset
+		makeUTXO(6, ids.GenerateTestID(), makeOut(1_000, 0)),
+		// expired stakeable lock
+		makeUTXO(7, assetID, &stakeable.LockOut{Locktime: now, TransferableOut: makeOut(50, 0)}),
+	}
+
+	t.Run("largest first", func(t *testing.T) {
+		selected, total, err := SelectUTXOs(utxos, assetID, 450, now)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(500), total)
+		assert.Equal(t, []*SelectedUTXO{
+			{ID: utxos[1].UTXOID.String(), Amount: 300},
+			{ID: utxos[2].UTXOID.String(), Amount: 200},
+		}, selected)
+	})
+
+	t.Run("expired stakeable lock is spendable", func(t *testing.T) {
+		selected, total, err := SelectUTXOs(utxos, assetID, 650, now)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(650), total)
+		assert.Len(t, selected, 4)
+		assert.Equal(t, utxos[7].UTXOID.String(), selected[3].ID)
+	})
+
+	t.Run("locked and multisig utxos are not spent", func(t *testing.T) {
+		_, _, err := SelectUTXOs(utxos, assetID, 651, now)
+		assert.Equal(t, errInsufficientFunds, err)
+	})
+
+	t.Run("zero amount is rejected", func(t *testing.T) {
+		_, _, err := SelectUTXOs(utxos, assetID, 0, now)
+		assert.Equal(t, errZeroTarget, err)
+	})
+}
@@ -0,0 +1,159 @@
+package gasprice
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+)
+
+const (
+	// DefaultSampledBlocks is how many of the latest blocks are sampled
+	DefaultSampledBlocks = 20
+	// DefaultCacheTTL is how long suggestions are served before the latest
+	// blocks are sampled again
+	DefaultCacheTTL = 2 * time.Second
+
+	slowPercentile   = 10
+	normalPercentile = 50
+	fastPercentile   = 90
+)
+
+// Oracle suggests gas prices from the base fees and the tips of the latest
+// blocks. Suggestions are cached for the cache TTL.
+type Oracle struct {
+	lock     sync.Mutex
+	client   client.Client
+	blocks   uint64
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	sampledAt          time.Time
+	slow, normal, fast *big.Int
+}
+
+// NewOracle returns a gas price oracle
+func NewOracle(client client.Client) *Oracle {
+	return &Oracle{
+		client:   client,
+		blocks:   DefaultSampledBlocks,
+		cacheTTL: DefaultCacheTTL,
+		now:      time.Now,
+	}
+}
+
+// SuggestGasPrices returns a slow, a normal and a fast gas price:
+//   - slow is the latest base fee with the 10th percentile tip, as a lower
+//     base fee would keep the transaction from being included
+//   - normal is the latest base fee with the median tip
+//   - fast is the highest sampled base fee with the 90th percentile tip, or the
+//     tip the latest block paid per gas for its block gas cost if higher
+func (o *Oracle) SuggestGasPrices(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
+	o.lock.Lock()
+	if o.slow != nil && o.now().Sub(o.sampledAt) < o.cacheTTL {
+		defer o.lock.Unlock()
+		return o.slow, o.normal, o.fast, nil
+	}
+	o.lock.Unlock()
+
+	// Blocks are fetched without holding the lock, concurrent callers missing
+	// the cache sample them on their own
+	slow, normal, fast, err := o.sample(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.sampledAt = o.now()
+	o.slow, o.normal, o.fast = slow, normal, fast
+	return slow, normal, fast, nil
+}
+
+// sample computes the suggestions from the latest blocks
+func (o *Oracle) sample(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
+	header, err := o.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var (
+		latest     *ethtypes.Block
+		tips       []*big.Int
+		maxBaseFee = new(big.Int)
+	)
+	for i := uint64(0); i < o.blocks && i <= header.Number.Uint64(); i++ {
+		number := new(big.Int).Sub(header.Number, new(big.Int).SetUint64(i))
+		block, err := o.client.BlockByNumber(ctx, number)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if i == 0 {
+			latest = block
+		}
+
+		baseFee := baseFeeOf(block)
+		if baseFee.Cmp(maxBaseFee) > 0 {
+			maxBaseFee = baseFee
+		}
+		for _, tx := range block.Transactions() {
+			tip, err := tx.EffectiveGasTip(block.BaseFee())
+			if err != nil {
+				continue
+			}
+			tips = append(tips, tip)
+		}
+	}
+	sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+
+	fastTip := percentile(tips, fastPercentile)
+	if blockTip := blockGasCostTip(latest); blockTip.Cmp(fastTip) > 0 {
+		fastTip = blockTip
+	}
+
+	slow := new(big.Int).Add(baseFeeOf(latest), percentile(tips, slowPercentile))
+	normal := maxOf(slow, new(big.Int).Add(baseFeeOf(latest), percentile(tips, normalPercentile)))
+	fast := maxOf(normal, new(big.Int).Add(maxBaseFee, fastTip))
+	return slow, normal, fast, nil
+}
+
+// baseFeeOf returns the base fee of block, zero before it was introduced
+func baseFeeOf(block *ethtypes.Block) *big.Int {
+	if block.BaseFee() == nil {
+		return new(big.Int)
+	}
+	return block.BaseFee()
+}
+
+// blockGasCostTip returns the tip per gas the transactions of block paid on
+// average to cover its block gas cost
+func blockGasCostTip(block *ethtypes.Block) *big.Int {
+	if block.BlockGasCost() == nil || block.GasUsed() == 0 {
+		return new(big.Int)
+	}
+
+	tip := new(big.Int).Mul(block.BlockGasCost(), baseFeeOf(block))
+	return tip.Div(tip, new(big.Int).SetUint64(block.GasUsed()))
+}
+
+// percentile returns the p-th percentile of the sorted values, zero if there
+// are none
+func percentile(sorted []*big.Int, p int) *big.Int {
+	if len(sorted) == 0 {
+		return new(big.Int)
+	}
+	return sorted[(len(sorted)-1)*p/100]
+}
+
+func maxOf(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) > 0 {
+		return a
+	}
+	return b
+}
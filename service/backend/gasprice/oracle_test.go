@@ -0,0 +1,123 @@
+package gasprice
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	mocks "github.com/ava-labs/avalanche-rosetta/mocks/client"
+)
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1_000_000_000))
+}
+
+func makeBlock(number int64, baseFee int64, blockGasCost int64, gasUsed uint64, tips ...int64) *ethtypes.Block {
+	txs := make([]*ethtypes.Transaction, 0, len(tips))
+	for i, tip := range tips {
+		txs = append(txs, ethtypes.NewTransaction(
+			uint64(i),
+			ethcommon.Address{},
+			big.NewInt(0),
+			21_000,
+			new(big.Int).Add(gwei(baseFee), gwei(tip)),
+			nil,
+		))
+	}
+
+	header := &ethtypes.Header{
+		Number:       big.NewInt(number),
+		BaseFee:      gwei(baseFee),
+		BlockGasCost: big.NewInt(blockGasCost),
+		GasUsed:      gasUsed,
+	}
+	return ethtypes.NewBlockWithHeader(header).WithBody(txs, nil, 0, nil)
+}
+
+func TestOracle(t *testing.T) {
+	ctx := context.Background()
+
+	newOracle := func(clientMock *mocks.Client, blocks ...*ethtypes.Block) *Oracle {
+		oracle := NewOracle(clientMock)
+		oracle.blocks = uint64(len(blocks))
+		for _, block := range blocks {
+			clientMock.On("BlockByNumber", ctx, block.Number()).Return(block, nil).Once()
+		}
+		return oracle
+	}
+
+	t.Run("percentile tips over sampled base fees", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		latest := makeBlock(12, 25, 0, 42_000, 1, 2)
+		oracle := newOracle(clientMock, latest, makeBlock(11, 30, 0, 42_000, 3, 4), makeBlock(10, 20, 0, 42_000, 0, 10))
+		clientMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(latest.Header(), nil).Once()
+
+		slow, normal, fast, err := oracle.SuggestGasPrices(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, gwei(25), slow)
+		assert.Equal(t, gwei(27), normal)
+		assert.Equal(t, gwei(34), fast)
+
+		// Suggestions are cached for the cache ttl without asking the node
+		slow, normal, fast, err = oracle.SuggestGasPrices(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, gwei(25), slow)
+		assert.Equal(t, gwei(27), normal)
+		assert.Equal(t, gwei(34), fast)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("suggestions are sampled again once the cache expires", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		oracle := newOracle(clientMock, makeBlock(12, 25, 0, 42_000, 1))
+		now := time.Now()
+		oracle.now = func() time.Time { return now }
+		clientMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(makeBlock(12, 25, 0, 0).Header(), nil).Once()
+
+		slow, _, _, err := oracle.SuggestGasPrices(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, gwei(26), slow)
+
+		now = now.Add(DefaultCacheTTL)
+		latest := makeBlock(13, 30, 0, 42_000, 1)
+		clientMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(latest.Header(), nil).Once()
+		clientMock.On("BlockByNumber", ctx, latest.Number()).Return(latest, nil).Once()
+
+		slow, _, _, err = oracle.SuggestGasPrices(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, gwei(31), slow)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("fast covers the block gas cost", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		latest := makeBlock(12, 25, 100_000, 50_000, 1, 2)
+		oracle := newOracle(clientMock, latest, makeBlock(11, 30, 0, 42_000, 3, 4))
+		clientMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(latest.Header(), nil).Once()
+
+		slow, normal, fast, err := oracle.SuggestGasPrices(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, gwei(26), slow)
+		assert.Equal(t, gwei(27), normal)
+		assert.Equal(t, gwei(80), fast)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("no transactions", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		latest := makeBlock(12, 25, 0, 0)
+		oracle := newOracle(clientMock, latest)
+		clientMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(latest.Header(), nil).Once()
+
+		slow, normal, fast, err := oracle.SuggestGasPrices(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, gwei(25), slow)
+		assert.Equal(t, gwei(25), normal)
+		assert.Equal(t, gwei(25), fast)
+	})
+}
@@ -0,0 +1,151 @@
+package nonce
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+)
+
+const (
+	// DefaultReservationTTL is how long a nonce stays reserved if no
+	// transaction using it reaches the tx pool
+	DefaultReservationTTL = 2 * time.Minute
+	// DefaultReconcileInterval is how often reservations are reconciled
+	// against the tx pool
+	DefaultReconcileInterval = 5 * time.Second
+)
+
+type reservation struct {
+	expiresAt time.Time
+	// pooled is set while the tx pool holds a transaction using the nonce
+	pooled bool
+}
+
+// Ledger hands out distinct nonces to concurrent constructions from the same
+// account. Nonces are reserved on top of the pending nonce of the account, and
+// released when they expire or once the account pending nonce moves past them.
+// Reservations are kept in memory and do not survive a restart.
+type Ledger struct {
+	lock         sync.Mutex
+	client       client.Client
+	reservations map[ethcommon.Address]map[uint64]*reservation
+
+	ttl               time.Duration
+	reconcileInterval time.Duration
+	now               func() time.Time
+}
+
+// NewLedger returns a nonce reservation ledger
+func NewLedger(client client.Client) *Ledger {
+	return &Ledger{
+		client:            client,
+		reservations:      map[ethcommon.Address]map[uint64]*reservation{},
+		ttl:               DefaultReservationTTL,
+		reconcileInterval: DefaultReconcileInterval,
+		now:               time.Now,
+	}
+}
+
+// ReserveNonce returns the lowest nonce of address, from its pending nonce
+// on, that is not reserved yet and reserves it
+func (l *Ledger) ReserveNonce(ctx context.Context, address ethcommon.Address) (uint64, error) {
+	pending, err := l.client.PendingNonceAt(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	reserved, ok := l.reservations[address]
+	if !ok {
+		reserved = map[uint64]*reservation{}
+		l.reservations[address] = reserved
+	}
+	for nonce, r := range reserved {
+		if nonce < pending || l.expired(r, now) {
+			delete(reserved, nonce)
+		}
+	}
+
+	nonce := pending
+	for reserved[nonce] != nil {
+		nonce++
+	}
+	reserved[nonce] = &reservation{expiresAt: now.Add(l.ttl)}
+	return nonce, nil
+}
+
+// Run reconciles reservations until ctx is cancelled
+func (l *Ledger) Run(ctx context.Context) {
+	ticker := time.NewTicker(l.reconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := l.Reconcile(ctx); err != nil {
+			zap.L().Warn("unable to reconcile nonce reservations", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile marks the reservations whose transaction is in the tx pool, so
+// that they do not expire while it is pending, and forgets the expired ones
+func (l *Ledger) Reconcile(ctx context.Context) error {
+	content, err := l.client.TxPoolContent(ctx)
+	if err != nil {
+		return err
+	}
+
+	pooled := map[ethcommon.Address]map[uint64]bool{}
+	for _, accountMap := range []client.TxAccountMap{content.Pending, content.Queued} {
+		for account, txs := range accountMap {
+			address := ethcommon.HexToAddress(account)
+			if pooled[address] == nil {
+				pooled[address] = map[uint64]bool{}
+			}
+			for nonce := range txs {
+				n, err := strconv.ParseUint(nonce, 10, 64)
+				if err != nil {
+					continue
+				}
+				pooled[address][n] = true
+			}
+		}
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	for address, reserved := range l.reservations {
+		for nonce, r := range reserved {
+			r.pooled = pooled[address][nonce]
+			if l.expired(r, now) {
+				delete(reserved, nonce)
+			}
+		}
+		if len(reserved) == 0 {
+			delete(l.reservations, address)
+		}
+	}
+	return nil
+}
+
+// expired returns true if r is past its expiry and no pooled transaction
+// uses its nonce
+func (l *Ledger) expired(r *reservation, now time.Time) bool {
+	return !r.pooled && !now.Before(r.expiresAt)
+}
@@ -0,0 +1,115 @@
+package nonce
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	mocks "github.com/ava-labs/avalanche-rosetta/mocks/client"
+)
+
+func TestLedger(t *testing.T) {
+	ctx := context.Background()
+	address := ethcommon.HexToAddress("0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309")
+	other := ethcommon.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+
+	newLedger := func(clientMock *mocks.Client) (*Ledger, *time.Time) {
+		now := time.Unix(1_000, 0)
+		ledger := NewLedger(clientMock)
+		ledger.now = func() time.Time { return now }
+		return ledger, &now
+	}
+
+	t.Run("concurrent reservations get distinct nonces", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		ledger, _ := newLedger(clientMock)
+		clientMock.On("PendingNonceAt", ctx, address).Return(uint64(5), nil).Times(3)
+		clientMock.On("PendingNonceAt", ctx, other).Return(uint64(0), nil).Once()
+
+		for _, expected := range []uint64{5, 6, 7} {
+			nonce, err := ledger.ReserveNonce(ctx, address)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, nonce)
+		}
+
+		nonce, err := ledger.ReserveNonce(ctx, other)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), nonce)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("reservations below the pending nonce are released", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		ledger, _ := newLedger(clientMock)
+		clientMock.On("PendingNonceAt", ctx, address).Return(uint64(5), nil).Twice()
+		clientMock.On("PendingNonceAt", ctx, address).Return(uint64(6), nil).Once()
+
+		for i := 0; i < 2; i++ {
+			_, err := ledger.ReserveNonce(ctx, address)
+			assert.NoError(t, err)
+		}
+
+		// The tx using nonce 5 was accepted, nonce 6 is still reserved
+		nonce, err := ledger.ReserveNonce(ctx, address)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(7), nonce)
+		assert.Len(t, ledger.reservations[address], 2)
+	})
+
+	t.Run("abandoned reservations expire", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		ledger, now := newLedger(clientMock)
+		clientMock.On("PendingNonceAt", ctx, address).Return(uint64(5), nil)
+
+		nonce, err := ledger.ReserveNonce(ctx, address)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), nonce)
+
+		*now = now.Add(DefaultReservationTTL)
+		nonce, err = ledger.ReserveNonce(ctx, address)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), nonce)
+	})
+
+	t.Run("pooled reservations do not expire", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		ledger, now := newLedger(clientMock)
+		clientMock.On("PendingNonceAt", ctx, address).Return(uint64(5), nil)
+
+		for i := 0; i < 2; i++ {
+			_, err := ledger.ReserveNonce(ctx, address)
+			assert.NoError(t, err)
+		}
+
+		// The tx using nonce 6 is queued behind the one of nonce 5, which was
+		// never submitted
+		queued := &client.TxPoolContent{
+			Queued: client.TxAccountMap{
+				strings.ToLower(address.Hex()): client.TxNonceMap{
+					"6": "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d: 1 wei + 21000 gas × 25000000000 wei",
+				},
+			},
+		}
+		clientMock.On("TxPoolContent", ctx).Return(queued, nil).Twice()
+		assert.NoError(t, ledger.Reconcile(ctx))
+
+		*now = now.Add(DefaultReservationTTL)
+		assert.NoError(t, ledger.Reconcile(ctx))
+		assert.Len(t, ledger.reservations[address], 1)
+
+		nonce, err := ledger.ReserveNonce(ctx, address)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), nonce)
+
+		// Once dropped from the pool, the expired reservation is released
+		clientMock.On("TxPoolContent", ctx).Return(&client.TxPoolContent{}, nil).Once()
+		assert.NoError(t, ledger.Reconcile(ctx))
+		assert.Len(t, ledger.reservations[address], 1)
+		assert.NotNil(t, ledger.reservations[address][5])
+	})
+}
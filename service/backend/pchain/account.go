@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/coinbase/rosetta-sdk-go/types"
 
 	"github.com/ava-labs/avalanche-rosetta/mapper"
@@ -32,6 +33,7 @@ var (
 	errNotStakeableOverflow       = errors.New("overflow while calculating locked not stakeable balance")
 	errLockedNotStakeableOverflow = errors.New("overflow while calculating locked not stakeable balance")
 	errUnlockedStakeableOverflow  = errors.New("overflow while calculating unlocked stakeable balance")
+	errMultisigOverflow           = errors.New("overflow while calculating multisig balance")
 )
 
 func (b *Backend) AccountBalance(ctx context.Context, req *types.AccountBalanceRequest) (*types.AccountBalanceResponse, *types.Error) {
@@ -63,6 +65,8 @@ func (b *Backend) AccountBalance(ctx context.Context, req *types.AccountBalanceR
 		balanceValue = balance.LockedNotStakeable
 	case pmapper.SubAccountTypeStaked:
 		balanceValue = balance.Staked
+	case pmapper.SubAccountTypeMultisig:
+		balanceValue = balance.Multisig
 	default:
 		balanceValue = balance.Total
 	}
@@ -105,6 +109,12 @@ func (b *Backend) AccountCoins(ctx context.Context, req *types.AccountCoinsReque
 		subAccountAddress = req.AccountIdentifier.SubAccount.Address
 	}
 	fetchSharedMemory := subAccountAddress == pmapper.SubAccountTypeSharedMemory
+	fetchMultisig := subAccountAddress == pmapper.SubAccountTypeMultisig
+
+	hrp, err := mapper.GetHRP(req.NetworkIdentifier)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, "unable to get hrp")
+	}
 
 	height, utxos, _, typedErr := b.fetchUTXOsAndStakedOutputs(ctx, addr, false, fetchSharedMemory)
 	if typedErr != nil {
@@ -112,7 +122,7 @@ func (b *Backend) AccountCoins(ctx context.Context, req *types.AccountCoinsReque
 	}
 
 	// convert raw UTXO bytes to Rosetta Coins
-	coins, err := b.processUtxos(currencyAssetIDs, utxos)
+	coins, err := b.processUtxos(hrp, currencyAssetIDs, utxos, fetchMultisig)
 	if err != nil {
 		return nil, service.WrapError(service.ErrInternalError, err)
 	}
@@ -142,7 +152,7 @@ func (b *Backend) fetchBalance(ctx context.Context, addrString string, fetchImpo
 		return 0, nil, typedErr
 	}
 
-	balance, err := b.getBalancesWithoutMultisig(utxos)
+	balance, err := b.getBalances(utxos)
 	if err != nil {
 		return 0, nil, service.WrapError(service.ErrInternalError, err)
 	}
@@ -160,9 +170,10 @@ func (b *Backend) fetchBalance(ctx context.Context, addrString string, fetchImpo
 }
 
 // Copy of the platformvm service's GetBalance implementation.
-// This is needed as multisig UTXOs are cleaned in parseUTXOs and its output must be used for the calculations. Ref:
+// This is needed as multisig UTXOs are accounted for separately in the multisig sub account
+// and are not part of the total balance. Ref:
 // https://github.com/ava-labs/avalanchego/blob/0950acab667e0c16a55e9a9bb72bcbe25c3b88cf/vms/platformvm/service.go#L184
-func (b *Backend) getBalancesWithoutMultisig(utxos []avax.UTXO) (*AccountBalance, error) {
+func (b *Backend) getBalances(utxos []avax.UTXO) (*AccountBalance, error) {
 	currentTime := uint64(time.Now().Unix())
 
 	accountBalance := &AccountBalance{
@@ -171,10 +182,24 @@ func (b *Backend) getBalancesWithoutMultisig(utxos []avax.UTXO) (*AccountBalance
 		Unlocked:           0,
 		LockedStakeable:    0,
 		LockedNotStakeable: 0,
+		Multisig:           0,
 	}
 
 utxoFor:
 	for _, utxo := range utxos {
+		if isMultisigUTXO(utxo) {
+			amounter, ok := utxo.Out.(avax.Amounter)
+			if !ok {
+				return nil, errUnableToGetUTXOOut
+			}
+			newBalance, err := math.Add64(accountBalance.Multisig, amounter.Amount())
+			if err != nil {
+				return nil, errMultisigOverflow
+			}
+			accountBalance.Multisig = newBalance
+			continue
+		}
+
 		switch out := utxo.Out.(type) {
 		case *secp256k1fx.TransferOutput:
 			if out.Locktime <= currentTime {
@@ -369,14 +394,9 @@ func (b *Backend) parseUTXOs(utxoBytes [][]byte) ([]avax.UTXO, error) {
 
 		utxoIDs[utxo.UTXOID.String()] = struct{}{}
 
-		// Skip multisig UTXOs
-		addressable, ok := utxo.Out.(avax.Addressable)
-		if !ok {
+		if _, ok := utxo.Out.(avax.Addressable); !ok {
 			return nil, errUnableToGetUTXOOut
 		}
-		if len(addressable.Addresses()) > 1 {
-			continue
-		}
 
 		utxos = append(utxos, utxo)
 	}
@@ -418,7 +438,15 @@ func (b *Backend) getAccountUTXOs(ctx context.Context, addr ids.ShortID, sourceC
 	return utxos, nil
 }
 
-func (b *Backend) processUtxos(currencyAssetIDs map[ids.ID]struct{}, utxos []avax.UTXO) ([]*types.Coin, error) {
+// processUtxos converts UTXOs to Rosetta coins.
+// Multisig UTXOs are only returned when fetchMultisig is set, in which case single signature UTXOs are skipped
+// and each coin carries the owners, threshold and locktime of the UTXO in its amount metadata.
+func (b *Backend) processUtxos(
+	hrp string,
+	currencyAssetIDs map[ids.ID]struct{},
+	utxos []avax.UTXO,
+	fetchMultisig bool,
+) ([]*types.Coin, error) {
 	var coins []*types.Coin
 	for _, utxo := range utxos {
 		// Skip UTXO if req.Currencies is specified but it doesn't contain the UTXOs asset
@@ -426,6 +454,10 @@ func (b *Backend) processUtxos(currencyAssetIDs map[ids.ID]struct{}, utxos []ava
 			continue
 		}
 
+		if isMultisigUTXO(utxo) != fetchMultisig {
+			continue
+		}
+
 		amounter, ok := utxo.Out.(avax.Amounter)
 		if !ok {
 			return nil, errUnableToGetUTXOOut
@@ -438,7 +470,53 @@ func (b *Backend) processUtxos(currencyAssetIDs map[ids.ID]struct{}, utxos []ava
 				Currency: mapper.AtomicAvaxCurrency,
 			},
 		}
+
+		if fetchMultisig {
+			metadata, err := ownersMetadata(hrp, utxo.Out)
+			if err != nil {
+				return nil, err
+			}
+			coin.Amount.Metadata = metadata
+		}
+
 		coins = append(coins, coin)
 	}
 	return coins, nil
 }
+
+func isMultisigUTXO(utxo avax.UTXO) bool {
+	addressable, ok := utxo.Out.(avax.Addressable)
+	return ok && len(addressable.Addresses()) > 1
+}
+
+// ownersMetadata returns the owners, threshold and locktime of the given output
+func ownersMetadata(hrp string, outIntf verify.State) (map[string]interface{}, error) {
+	var locktime uint64
+	if lockedOut, ok := outIntf.(*stakeable.LockOut); ok {
+		locktime = lockedOut.Locktime
+		outIntf = lockedOut.TransferableOut
+	}
+
+	out, ok := outIntf.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return nil, errUnableToGetUTXOOut
+	}
+	if out.Locktime > locktime {
+		locktime = out.Locktime
+	}
+
+	owners := make([]string, 0, len(out.Addrs))
+	for _, addr := range out.Addrs {
+		owner, err := address.Format(mapper.PChainNetworkIdentifier, hrp, addr[:])
+		if err != nil {
+			return nil, err
+		}
+		owners = append(owners, owner)
+	}
+
+	return map[string]interface{}{
+		pmapper.MetadataOwners:    owners,
+		pmapper.MetadataThreshold: out.Threshold,
+		pmapper.MetadataLocktime:  locktime,
+	}, nil
+}
@@ -39,7 +39,7 @@ func TestAccountBalance(t *testing.T) {
 	pChainMock := &mocks.PChainClient{}
 	parserMock := &idxmocks.Parser{}
 	parserMock.Mock.On("ParseBlockAtIndex", ctx, blockHeight).Return(parsedBlock, nil)
-	backend := NewBackend(pChainMock, parserMock, ids.Empty, nil)
+	backend := NewBackend(pChainMock, parserMock, ids.Empty, nil, nil, false, nil)
 	backend.getUTXOsPageSize = 2
 
 	t.Run("Account Balance Test", func(t *testing.T) {
@@ -128,6 +128,52 @@ func TestAccountBalance(t *testing.T) {
 		pChainMock.AssertExpectations(t)
 		parserMock.AssertExpectations(t)
 	})
+
+	t.Run("Account Balance Test multisig", func(t *testing.T) {
+		pChainAddr := "P-fuji1wmd9dfrqpud6daq0cde47u0r7pkrr46ep60399"
+		addr, _ := address.ParseToID(pChainAddr)
+		utxo0Bytes := makeUtxoBytes(t, backend, utxos[0].id, utxos[0].amount)
+		utxo1Bytes := makeMultisigUtxoBytes(t, backend, utxos[1].id, utxos[1].amount, []ids.ShortID{addr, ids.ShortID{1}})
+
+		pChainMock.Mock.On("GetHeight", ctx).Return(blockHeight, nil).Twice()
+		pChainMock.Mock.On("GetAtomicUTXOs", ctx, []ids.ShortID{addr}, "", uint32(2), ids.ShortEmpty, ids.Empty).
+			Return([][]byte{utxo0Bytes, utxo1Bytes}, addr, ids.Empty, nil).Once()
+		pChainMock.Mock.On("GetAtomicUTXOs", ctx, []ids.ShortID{addr}, "", uint32(2), addr, ids.Empty).
+			Return([][]byte{}, addr, ids.Empty, nil).Once()
+
+		resp, err := backend.AccountBalance(
+			ctx,
+			&types.AccountBalanceRequest{
+				NetworkIdentifier: &types.NetworkIdentifier{
+					Network: mapper.FujiNetwork,
+					SubNetworkIdentifier: &types.SubNetworkIdentifier{
+						Network: mapper.PChainNetworkIdentifier,
+					},
+				},
+				AccountIdentifier: &types.AccountIdentifier{
+					Address:    pChainAddr,
+					SubAccount: &types.SubAccountIdentifier{Address: pmapper.SubAccountTypeMultisig},
+				},
+				Currencies: []*types.Currency{
+					mapper.AtomicAvaxCurrency,
+				},
+			},
+		)
+
+		expected := &types.AccountBalanceResponse{
+			Balances: []*types.Amount{
+				{
+					Value:    "2000000000", // only the multisig UTXO
+					Currency: mapper.AtomicAvaxCurrency,
+				},
+			},
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected.Balances, resp.Balances)
+		pChainMock.AssertExpectations(t)
+		parserMock.AssertExpectations(t)
+	})
 }
 
 func TestAccountCoins(t *testing.T) {
@@ -135,7 +181,7 @@ func TestAccountCoins(t *testing.T) {
 	pChainMock := &mocks.PChainClient{}
 	parserMock := &idxmocks.Parser{}
 	parserMock.Mock.On("ParseBlockAtIndex", ctx, blockHeight).Return(parsedBlock, nil)
-	backend := NewBackend(pChainMock, parserMock, ids.Empty, nil)
+	backend := NewBackend(pChainMock, parserMock, ids.Empty, nil, nil, false, nil)
 
 	t.Run("Account Coins Test regular coins", func(t *testing.T) {
 		pChainAddr := "P-fuji1wmd9dfrqpud6daq0cde47u0r7pkrr46ep60399"
@@ -293,6 +339,77 @@ func TestAccountCoins(t *testing.T) {
 		pChainMock.AssertExpectations(t)
 		parserMock.AssertExpectations(t)
 	})
+
+	t.Run("Account Coins Test multisig coins", func(t *testing.T) {
+		pChainAddr := "P-fuji1wmd9dfrqpud6daq0cde47u0r7pkrr46ep60399"
+		pChainAddrId, errp := address.ParseToID(pChainAddr)
+		assert.Nil(t, errp)
+		otherOwner := ids.ShortID{1}
+		otherOwnerAddr, errf := address.Format(mapper.PChainNetworkIdentifier, "fuji", otherOwner[:])
+		assert.Nil(t, errf)
+
+		mockAssetDescription := &avm.GetAssetDescriptionReply{
+			Name:         "Avalanche",
+			Symbol:       mapper.AtomicAvaxCurrency.Symbol,
+			Denomination: 9,
+		}
+		pChainMock.Mock.On("GetAssetDescription", ctx, mapper.AtomicAvaxCurrency.Symbol).Return(mockAssetDescription, nil)
+
+		utxo0Bytes := makeUtxoBytes(t, backend, utxos[0].id, utxos[0].amount)
+		utxo1Bytes := makeMultisigUtxoBytes(t, backend, utxos[1].id, utxos[1].amount, []ids.ShortID{otherOwner, pChainAddrId})
+
+		pChainMock.Mock.On("GetHeight", ctx).Return(blockHeight, nil).Twice()
+		pageSize := uint32(1024)
+		backend.getUTXOsPageSize = pageSize
+		pChainMock.Mock.On("GetAtomicUTXOs", ctx, []ids.ShortID{pChainAddrId}, "", pageSize, ids.ShortEmpty, ids.Empty).
+			Return([][]byte{utxo0Bytes, utxo1Bytes}, pChainAddrId, ids.Empty, nil).Once()
+
+		resp, err := backend.AccountCoins(
+			ctx,
+			&types.AccountCoinsRequest{
+				NetworkIdentifier: &types.NetworkIdentifier{
+					Network: mapper.FujiNetwork,
+					SubNetworkIdentifier: &types.SubNetworkIdentifier{
+						Network: mapper.PChainNetworkIdentifier,
+					},
+				},
+				AccountIdentifier: &types.AccountIdentifier{
+					Address:    pChainAddr,
+					SubAccount: &types.SubAccountIdentifier{Address: pmapper.SubAccountTypeMultisig},
+				},
+				Currencies: []*types.Currency{
+					mapper.AtomicAvaxCurrency,
+				},
+			})
+
+		expected := &types.AccountCoinsResponse{
+			BlockIdentifier: &types.BlockIdentifier{
+				Index: int64(blockHeight),
+				Hash:  parsedBlock.BlockID.String(),
+			},
+			Coins: []*types.Coin{
+				{
+					CoinIdentifier: &types.CoinIdentifier{
+						Identifier: "pyQfA1Aq9vLaDETjeQe5DAwVxr2KAYdHg4CHzawmaj9oA6ppn:0",
+					},
+					Amount: &types.Amount{
+						Value:    "2000000000",
+						Currency: mapper.AtomicAvaxCurrency,
+						Metadata: map[string]interface{}{
+							pmapper.MetadataOwners:    []string{otherOwnerAddr, pChainAddr},
+							pmapper.MetadataThreshold: uint32(2),
+							pmapper.MetadataLocktime:  uint64(0),
+						},
+					},
+				},
+			},
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected, resp)
+		pChainMock.AssertExpectations(t)
+		parserMock.AssertExpectations(t)
+	})
 }
 
 func makeUtxoBytes(t *testing.T, backend *Backend, utxoIdStr string, amount uint64) []byte {
@@ -313,6 +430,31 @@ func makeUtxoBytes(t *testing.T, backend *Backend, utxoIdStr string, amount uint
 	return utxoBytes
 }
 
+func makeMultisigUtxoBytes(t *testing.T, backend *Backend, utxoIdStr string, amount uint64, owners []ids.ShortID) []byte {
+	utxoId, err := mapper.DecodeUTXOID(utxoIdStr)
+	if err != nil {
+		t.Fail()
+		return nil
+	}
+
+	ids.SortShortIDs(owners)
+	utxoBytes, err := backend.codec.Marshal(0, &avax.UTXO{
+		UTXOID: *utxoId,
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: uint32(len(owners)),
+				Addrs:     owners,
+			},
+		},
+	})
+	if err != nil {
+		t.Fail()
+	}
+
+	return utxoBytes
+}
+
 func makeStakeUtxoBytes(t *testing.T, backend *Backend, amount uint64) []byte {
 	utxoBytes, err := backend.codec.Marshal(0, &avax.TransferableOutput{
 		Out: &secp256k1fx.TransferOutput{Amt: amount},
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanche-rosetta/client"
 	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
 	"github.com/ava-labs/avalanche-rosetta/service"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/common"
 	"github.com/ava-labs/avalanche-rosetta/service/backend/pchain/indexer"
 )
 
@@ -30,6 +31,9 @@ type Backend struct {
 	genesisBlock           *indexer.ParsedGenesisBlock
 	genesisBlockIdentifier *types.BlockIdentifier
 	chainIDs               map[string]string
+	networkConstants       *pmapper.NetworkConstants
+	offline                bool
+	mempool                *common.Mempool
 }
 
 func (b *Backend) getGenesisBlock(ctx context.Context) (*indexer.ParsedGenesisBlock, error) {
@@ -54,13 +58,20 @@ func (b *Backend) buildGenesisBlockIdentifier(genesisBlock *indexer.ParsedGenesi
 	}
 }
 
+// NewBackend returns a P-chain backend. Values found in networkConstants are
+// used instead of querying the node, so that transactions can be built offline.
+// An offline backend never queries the node for tx fees. Submitted txs stay in
+// the mempool until submissions reports them as decided.
 func NewBackend(
 	pClient client.PChainClient,
 	indexerParser indexer.Parser,
 	assetID ids.ID,
 	networkIdentifier *types.NetworkIdentifier,
+	networkConstants *pmapper.NetworkConstants,
+	offline bool,
+	submissions common.SubmissionStatuses,
 ) *Backend {
-	return &Backend{
+	b := &Backend{
 		fac:               &crypto.FactorySECP256K1R{},
 		pClient:           pClient,
 		networkIdentifier: networkIdentifier,
@@ -70,7 +81,11 @@ func NewBackend(
 		avaxAssetID:       assetID,
 		indexerParser:     indexerParser,
 		chainIDs:          nil,
+		networkConstants:  networkConstants,
+		offline:           offline,
 	}
+	b.mempool = common.NewMempool(submissions)
+	return b
 }
 
 func (*Backend) ShouldHandleRequest(req interface{}) bool {
@@ -101,6 +116,10 @@ func (*Backend) ShouldHandleRequest(req interface{}) bool {
 		return pmapper.IsPChain(r.NetworkIdentifier)
 	case *types.NetworkRequest:
 		return pmapper.IsPChain(r.NetworkIdentifier)
+	case *types.CallRequest:
+		return pmapper.IsPChain(r.NetworkIdentifier)
+	case *types.MempoolTransactionRequest:
+		return pmapper.IsPChain(r.NetworkIdentifier)
 	}
 
 	return false
@@ -57,6 +57,11 @@ func (b *Backend) Block(ctx context.Context, request *types.BlockRequest) (*type
 	}
 	blockIndex = int64(block.Height)
 
+	// Txs included in an accepted block are no longer pending
+	for _, tx := range block.Txs {
+		b.mempool.Remove(tx.ID())
+	}
+
 	transactions, err := b.parseTransactions(ctx, request.NetworkIdentifier, block.Txs)
 	if err != nil {
 		return nil, service.WrapError(service.ErrInternalError, err)
@@ -262,13 +267,13 @@ func (b *Backend) getChainIDs(ctx context.Context) (map[string]string, error) {
 			ids.Empty.String(): mapper.PChainNetworkIdentifier,
 		}
 
-		cChainID, err := b.pClient.GetBlockchainID(ctx, mapper.CChainNetworkIdentifier)
+		cChainID, err := b.getBlockchainID(ctx, mapper.CChainNetworkIdentifier)
 		if err != nil {
 			return nil, err
 		}
 		b.chainIDs[cChainID.String()] = mapper.CChainNetworkIdentifier
 
-		xChainID, err := b.pClient.GetBlockchainID(ctx, mapper.XChainNetworkIdentifier)
+		xChainID, err := b.getBlockchainID(ctx, mapper.XChainNetworkIdentifier)
 		if err != nil {
 			return nil, err
 		}
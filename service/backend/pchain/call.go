@@ -0,0 +1,381 @@
+package pchain
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+type staker struct {
+	TxID            string    `json:"tx_id"`
+	NodeID          string    `json:"node_id"`
+	StartTime       uint64    `json:"start_time"`
+	EndTime         uint64    `json:"end_time"`
+	StakeAmount     string    `json:"stake_amount,omitempty"`
+	Weight          string    `json:"weight,omitempty"`
+	PotentialReward string    `json:"potential_reward,omitempty"`
+	RewardOwner     *owner    `json:"reward_owner,omitempty"`
+	DelegationFee   float32   `json:"delegation_fee,omitempty"`
+	Uptime          *float32  `json:"uptime,omitempty"`
+	Connected       *bool     `json:"connected,omitempty"`
+	Delegators      []*staker `json:"delegators,omitempty"`
+}
+
+type owner struct {
+	Addresses []string `json:"addresses"`
+	Threshold uint32   `json:"threshold"`
+	Locktime  uint64   `json:"locktime"`
+}
+
+// Call implements /call endpoint for P-chain
+func (b *Backend) Call(ctx context.Context, req *types.CallRequest) (*types.CallResponse, *types.Error) {
+	switch req.Method {
+	case pmapper.CallGetCurrentValidators:
+		return b.callGetCurrentValidators(ctx, req)
+	case pmapper.CallGetPendingValidators:
+		return b.callGetPendingValidators(ctx, req)
+	case pmapper.CallGetStake:
+		return b.callGetStake(ctx, req)
+	case pmapper.CallGetRewardUTXOs:
+		return b.callGetRewardUTXOs(ctx, req)
+	case pmapper.CallGetMinStake:
+		return b.callGetMinStake(ctx)
+	case mapper.CallSelectUTXOs:
+		return b.callSelectUTXOs(ctx, req)
+	default:
+		return nil, service.ErrCallInvalidMethod
+	}
+}
+
+func (b *Backend) callGetCurrentValidators(ctx context.Context, req *types.CallRequest) (*types.CallResponse, *types.Error) {
+	subnetID, nodeIDs, tErr := parseValidatorsCallInput(req)
+	if tErr != nil {
+		return nil, tErr
+	}
+
+	hrp, err := mapper.GetHRP(req.NetworkIdentifier)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, "unable to get hrp")
+	}
+
+	validators, err := b.pClient.GetCurrentValidators(ctx, subnetID, nodeIDs)
+	if err != nil {
+		return nil, service.WrapError(service.ErrClientError, err)
+	}
+
+	result := make([]*staker, 0, len(validators))
+	for _, v := range validators {
+		validator, err := newStaker(hrp, v.ClientStaker, v.RewardOwner, v.PotentialReward)
+		if err != nil {
+			return nil, service.WrapError(service.ErrInternalError, err)
+		}
+		validator.DelegationFee = v.DelegationFee
+		validator.Uptime = v.Uptime
+		validator.Connected = v.Connected
+
+		for _, d := range v.Delegators {
+			delegator, err := newStaker(hrp, d.ClientStaker, d.RewardOwner, d.PotentialReward)
+			if err != nil {
+				return nil, service.WrapError(service.ErrInternalError, err)
+			}
+			validator.Delegators = append(validator.Delegators, delegator)
+		}
+
+		result = append(result, validator)
+	}
+
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"validators": result,
+		},
+	}, nil
+}
+
+func (b *Backend) callGetPendingValidators(ctx context.Context, req *types.CallRequest) (*types.CallResponse, *types.Error) {
+	subnetID, nodeIDs, tErr := parseValidatorsCallInput(req)
+	if tErr != nil {
+		return nil, tErr
+	}
+
+	hrp, err := mapper.GetHRP(req.NetworkIdentifier)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, "unable to get hrp")
+	}
+
+	validators, delegators, err := b.pClient.GetPendingValidators(ctx, subnetID, nodeIDs)
+	if err != nil {
+		return nil, service.WrapError(service.ErrClientError, err)
+	}
+
+	// pending stakers are returned untyped by the client, decode them
+	// with the API types of the node
+	validatorsResult := make([]*staker, 0, len(validators))
+	for _, v := range validators {
+		var apiValidator platformvm.APIPrimaryValidator
+		if err := decodeAPIStaker(v, &apiValidator); err != nil {
+			return nil, service.WrapError(service.ErrInternalError, err)
+		}
+
+		validator, err := newStaker(hrp, clientStaker(apiValidator.APIStaker), nil, nil)
+		if err != nil {
+			return nil, service.WrapError(service.ErrInternalError, err)
+		}
+		validator.DelegationFee = float32(apiValidator.DelegationFee)
+		validator.Connected = &apiValidator.Connected
+
+		validatorsResult = append(validatorsResult, validator)
+	}
+
+	delegatorsResult := make([]*staker, 0, len(delegators))
+	for _, d := range delegators {
+		var apiDelegator platformvm.APIStaker
+		if err := decodeAPIStaker(d, &apiDelegator); err != nil {
+			return nil, service.WrapError(service.ErrInternalError, err)
+		}
+
+		delegator, err := newStaker(hrp, clientStaker(apiDelegator), nil, nil)
+		if err != nil {
+			return nil, service.WrapError(service.ErrInternalError, err)
+		}
+
+		delegatorsResult = append(delegatorsResult, delegator)
+	}
+
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"validators": validatorsResult,
+			"delegators": delegatorsResult,
+		},
+	}, nil
+}
+
+func (b *Backend) callGetStake(ctx context.Context, req *types.CallRequest) (*types.CallResponse, *types.Error) {
+	var input pmapper.StakeCallInput
+	if err := types.UnmarshalMap(req.Parameters, &input); err != nil {
+		return nil, service.WrapError(service.ErrCallInvalidParams, err)
+	}
+	if len(input.Addresses) == 0 {
+		return nil, service.WrapError(service.ErrCallInvalidParams, "addresses missing from params")
+	}
+
+	addrs := make([]ids.ShortID, 0, len(input.Addresses))
+	for _, addrStr := range input.Addresses {
+		addr, err := address.ParseToID(addrStr)
+		if err != nil {
+			return nil, service.WrapError(service.ErrCallInvalidParams, "unable to convert address")
+		}
+		addrs = append(addrs, addr)
+	}
+
+	hrp, err := mapper.GetHRP(req.NetworkIdentifier)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, "unable to get hrp")
+	}
+
+	staked, stakedOutputBytes, err := b.pClient.GetStake(ctx, addrs)
+	if err != nil {
+		return nil, service.WrapError(service.ErrClientError, err)
+	}
+
+	outputs := make([]*types.Amount, 0, len(stakedOutputBytes))
+	for _, outputBytes := range stakedOutputBytes {
+		output := avax.TransferableOutput{}
+		if _, err := b.codec.Unmarshal(outputBytes, &output); err != nil {
+			return nil, service.WrapError(service.ErrInternalError, errUnableToParseUTXO)
+		}
+
+		metadata, err := ownersMetadata(hrp, output.Out)
+		if err != nil {
+			return nil, service.WrapError(service.ErrInternalError, err)
+		}
+
+		outputs = append(outputs, &types.Amount{
+			Value:    strconv.FormatUint(output.Out.Amount(), 10),
+			Currency: mapper.AtomicAvaxCurrency,
+			Metadata: metadata,
+		})
+	}
+
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"staked":         strconv.FormatUint(staked, 10),
+			"staked_outputs": outputs,
+		},
+	}, nil
+}
+
+func (b *Backend) callGetRewardUTXOs(ctx context.Context, req *types.CallRequest) (*types.CallResponse, *types.Error) {
+	var input pmapper.RewardUTXOsCallInput
+	if err := types.UnmarshalMap(req.Parameters, &input); err != nil {
+		return nil, service.WrapError(service.ErrCallInvalidParams, err)
+	}
+	if len(input.TxID) == 0 {
+		return nil, service.WrapError(service.ErrCallInvalidParams, "tx_id missing from params")
+	}
+
+	txID, err := ids.FromString(input.TxID)
+	if err != nil {
+		return nil, service.WrapError(service.ErrCallInvalidParams, err)
+	}
+
+	hrp, err := mapper.GetHRP(req.NetworkIdentifier)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, "unable to get hrp")
+	}
+
+	utxoBytes, err := b.pClient.GetRewardUTXOs(ctx, &api.GetTxArgs{
+		TxID:     txID,
+		Encoding: formatting.Hex,
+	})
+	if err != nil {
+		return nil, service.WrapError(service.ErrClientError, err)
+	}
+
+	utxos, err := b.parseUTXOs(utxoBytes)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+
+	coins := make([]*types.Coin, 0, len(utxos))
+	for _, utxo := range utxos {
+		amounter, ok := utxo.Out.(avax.Amounter)
+		if !ok {
+			return nil, service.WrapError(service.ErrInternalError, errUnableToGetUTXOOut)
+		}
+
+		metadata, err := ownersMetadata(hrp, utxo.Out)
+		if err != nil {
+			return nil, service.WrapError(service.ErrInternalError, err)
+		}
+
+		coins = append(coins, &types.Coin{
+			CoinIdentifier: &types.CoinIdentifier{Identifier: utxo.UTXOID.String()},
+			Amount: &types.Amount{
+				Value:    strconv.FormatUint(amounter.Amount(), 10),
+				Currency: mapper.AtomicAvaxCurrency,
+				Metadata: metadata,
+			},
+		})
+	}
+
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"utxos": coins,
+		},
+		Idempotent: true,
+	}, nil
+}
+
+func (b *Backend) callGetMinStake(ctx context.Context) (*types.CallResponse, *types.Error) {
+	minValidatorStake, minDelegatorStake, err := b.pClient.GetMinStake(ctx)
+	if err != nil {
+		return nil, service.WrapError(service.ErrClientError, err)
+	}
+
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"min_validator_stake": strconv.FormatUint(minValidatorStake, 10),
+			"min_delegator_stake": strconv.FormatUint(minDelegatorStake, 10),
+		},
+	}, nil
+}
+
+func parseValidatorsCallInput(req *types.CallRequest) (ids.ID, []ids.NodeID, *types.Error) {
+	var input pmapper.ValidatorsCallInput
+	if err := types.UnmarshalMap(req.Parameters, &input); err != nil {
+		return ids.Empty, nil, service.WrapError(service.ErrCallInvalidParams, err)
+	}
+
+	subnetID := ids.Empty
+	if len(input.SubnetID) > 0 {
+		var err error
+		subnetID, err = ids.FromString(input.SubnetID)
+		if err != nil {
+			return ids.Empty, nil, service.WrapError(service.ErrCallInvalidParams, err)
+		}
+	}
+
+	nodeIDs := make([]ids.NodeID, 0, len(input.NodeIDs))
+	for _, nodeIDStr := range input.NodeIDs {
+		nodeID, err := ids.NodeIDFromString(nodeIDStr)
+		if err != nil {
+			return ids.Empty, nil, service.WrapError(service.ErrCallInvalidParams, err)
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+
+	return subnetID, nodeIDs, nil
+}
+
+func newStaker(
+	hrp string,
+	clientStaker platformvm.ClientStaker,
+	rewardOwner *platformvm.ClientOwner,
+	potentialReward *uint64,
+) (*staker, error) {
+	s := &staker{
+		TxID:            clientStaker.TxID.String(),
+		NodeID:          clientStaker.NodeID.String(),
+		StartTime:       clientStaker.StartTime,
+		EndTime:         clientStaker.EndTime,
+		StakeAmount:     formatOptionalUint(clientStaker.StakeAmount),
+		Weight:          formatOptionalUint(clientStaker.Weight),
+		PotentialReward: formatOptionalUint(potentialReward),
+	}
+
+	if rewardOwner != nil {
+		addrs := make([]string, 0, len(rewardOwner.Addresses))
+		for _, addr := range rewardOwner.Addresses {
+			addrStr, err := address.Format(mapper.PChainNetworkIdentifier, hrp, addr[:])
+			if err != nil {
+				return nil, err
+			}
+			addrs = append(addrs, addrStr)
+		}
+		s.RewardOwner = &owner{
+			Addresses: addrs,
+			Threshold: rewardOwner.Threshold,
+			Locktime:  rewardOwner.Locktime,
+		}
+	}
+
+	return s, nil
+}
+
+func decodeAPIStaker(raw interface{}, apiStaker interface{}) error {
+	bytes, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, apiStaker)
+}
+
+func clientStaker(apiStaker platformvm.APIStaker) platformvm.ClientStaker {
+	return platformvm.ClientStaker{
+		TxID:        apiStaker.TxID,
+		StartTime:   uint64(apiStaker.StartTime),
+		EndTime:     uint64(apiStaker.EndTime),
+		Weight:      (*uint64)(apiStaker.Weight),
+		StakeAmount: (*uint64)(apiStaker.StakeAmount),
+		NodeID:      apiStaker.NodeID,
+	}
+}
+
+func formatOptionalUint(value *uint64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatUint(*value, 10)
+}
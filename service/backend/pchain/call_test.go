@@ -0,0 +1,224 @@
+package pchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
+	mocks "github.com/ava-labs/avalanche-rosetta/mocks/client"
+	idxmocks "github.com/ava-labs/avalanche-rosetta/mocks/service/backend/pchain/indexer"
+	"github.com/ava-labs/avalanche-rosetta/service"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/common"
+)
+
+func TestCall(t *testing.T) {
+	ctx := context.Background()
+	pChainMock := &mocks.PChainClient{}
+	parserMock := &idxmocks.Parser{}
+	backend := NewBackend(pChainMock, parserMock, ids.Empty, nil, nil, false, nil)
+
+	t.Run("get min stake", func(t *testing.T) {
+		pChainMock.Mock.On("GetMinStake", ctx).Return(uint64(2000000000000), uint64(25000000000), nil).Once()
+
+		resp, err := backend.Call(ctx, &types.CallRequest{
+			NetworkIdentifier: pChainNetworkIdentifier,
+			Method:            pmapper.CallGetMinStake,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"min_validator_stake": "2000000000000",
+			"min_delegator_stake": "25000000000",
+		}, resp.Result)
+		pChainMock.AssertExpectations(t)
+	})
+
+	t.Run("get stake", func(t *testing.T) {
+		pChainAddr := "P-fuji1wmd9dfrqpud6daq0cde47u0r7pkrr46ep60399"
+		addr, _ := address.ParseToID(pChainAddr)
+		stakeUtxoBytes := makeStakeUtxoBytes(t, backend, utxos[1].amount)
+
+		pChainMock.Mock.On("GetStake", ctx, []ids.ShortID{addr}).
+			Return(utxos[1].amount, [][]byte{stakeUtxoBytes}, nil).Once()
+
+		resp, err := backend.Call(ctx, &types.CallRequest{
+			NetworkIdentifier: pChainNetworkIdentifier,
+			Method:            pmapper.CallGetStake,
+			Parameters: map[string]interface{}{
+				"addresses": []interface{}{pChainAddr},
+			},
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "2000000000", resp.Result["staked"])
+		outputs := resp.Result["staked_outputs"].([]*types.Amount)
+		assert.Len(t, outputs, 1)
+		assert.Equal(t, "2000000000", outputs[0].Value)
+		pChainMock.AssertExpectations(t)
+	})
+
+	t.Run("get stake requires addresses", func(t *testing.T) {
+		resp, err := backend.Call(ctx, &types.CallRequest{
+			NetworkIdentifier: pChainNetworkIdentifier,
+			Method:            pmapper.CallGetStake,
+			Parameters:        map[string]interface{}{},
+		})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, service.ErrCallInvalidParams.Code, err.Code)
+	})
+
+	t.Run("get pending validators", func(t *testing.T) {
+		nodeID := "NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg"
+		parsedNodeID, _ := ids.NodeIDFromString(nodeID)
+		txID := "mq1enPCRAwWyRjFNY8nSmkLde6U5huUcp9PXueF2h7Kjb2csd"
+		validators := []interface{}{map[string]interface{}{
+			"txID":          txID,
+			"nodeID":        nodeID,
+			"startTime":     "1656000000",
+			"endTime":       "1657000000",
+			"stakeAmount":   "2000000000000",
+			"delegationFee": "2.0000",
+			"connected":     true,
+		}}
+		delegators := []interface{}{map[string]interface{}{
+			"txID":        txID,
+			"nodeID":      nodeID,
+			"startTime":   "1656000000",
+			"endTime":     "1656500000",
+			"stakeAmount": "25000000000",
+		}}
+
+		pChainMock.Mock.On("GetPendingValidators", ctx, ids.Empty, []ids.NodeID{parsedNodeID}).
+			Return(validators, delegators, nil).Once()
+
+		resp, err := backend.Call(ctx, &types.CallRequest{
+			NetworkIdentifier: pChainNetworkIdentifier,
+			Method:            pmapper.CallGetPendingValidators,
+			Parameters: map[string]interface{}{
+				"node_ids": []interface{}{nodeID},
+			},
+		})
+
+		assert.Nil(t, err)
+		connected := true
+		assert.Equal(t, []*staker{{
+			TxID:          txID,
+			NodeID:        nodeID,
+			StartTime:     1656000000,
+			EndTime:       1657000000,
+			StakeAmount:   "2000000000000",
+			DelegationFee: 2,
+			Connected:     &connected,
+		}}, resp.Result["validators"])
+		assert.Equal(t, []*staker{{
+			TxID:        txID,
+			NodeID:      nodeID,
+			StartTime:   1656000000,
+			EndTime:     1656500000,
+			StakeAmount: "25000000000",
+		}}, resp.Result["delegators"])
+		pChainMock.AssertExpectations(t)
+	})
+
+	t.Run("select utxos", func(t *testing.T) {
+		pChainAddr := "P-fuji1wmd9dfrqpud6daq0cde47u0r7pkrr46ep60399"
+		addr, _ := address.ParseToID(pChainAddr)
+
+		var utxoBytes [][]byte
+		for _, u := range utxos {
+			utxoID, err := mapper.DecodeUTXOID(u.id)
+			assert.Nil(t, err)
+			bytes, err := backend.codec.Marshal(0, &avax.UTXO{
+				UTXOID: *utxoID,
+				Out: &secp256k1fx.TransferOutput{
+					Amt: u.amount,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr},
+					},
+				},
+			})
+			assert.Nil(t, err)
+			utxoBytes = append(utxoBytes, bytes)
+		}
+
+		pChainMock.Mock.On("GetAtomicUTXOs", ctx, []ids.ShortID{addr}, "", backend.getUTXOsPageSize, ids.ShortEmpty, ids.Empty).
+			Return(utxoBytes, addr, ids.Empty, nil).Once()
+		pChainMock.Mock.On("GetTxFee", ctx).Return(&info.GetTxFeeResponse{TxFee: 1000000}, nil).Once()
+
+		resp, err := backend.Call(ctx, &types.CallRequest{
+			NetworkIdentifier: pChainNetworkIdentifier,
+			Method:            mapper.CallSelectUTXOs,
+			Parameters: map[string]interface{}{
+				"address":        pChainAddr,
+				"amount":         "2500000000",
+				"operation_type": pmapper.OpExportAvax,
+			},
+		})
+		assert.Nil(t, err)
+
+		// the export fee is added to the amount
+		var result common.SelectUTXOsResult
+		assert.Nil(t, mapper.UnmarshalJSONMap(resp.Result, &result))
+		assert.Equal(t, "3000000000", result.Total)
+		assert.Equal(t, "499000000", result.Change)
+		assert.Len(t, result.Operations, 3)
+
+		// the largest utxo is selected first
+		assert.Equal(t, utxos[1].id, result.Operations[0].CoinChange.CoinIdentifier.Identifier)
+		assert.Equal(t, "-2000000000", result.Operations[0].Amount.Value)
+		assert.Equal(t, pmapper.OpTypeInput, result.Operations[0].Metadata["type"])
+		assert.Equal(t, utxos[0].id, result.Operations[1].CoinChange.CoinIdentifier.Identifier)
+		assert.Equal(t, "499000000", result.Operations[2].Amount.Value)
+		assert.Equal(t, pmapper.OpTypeOutput, result.Operations[2].Metadata["type"])
+		for _, op := range result.Operations {
+			assert.Equal(t, pmapper.OpExportAvax, op.Type)
+			assert.Equal(t, pChainAddr, op.Account.Address)
+		}
+		pChainMock.AssertExpectations(t)
+	})
+
+	t.Run("select utxos with insufficient funds", func(t *testing.T) {
+		pChainAddr := "P-fuji1wmd9dfrqpud6daq0cde47u0r7pkrr46ep60399"
+		addr, _ := address.ParseToID(pChainAddr)
+
+		pChainMock.Mock.On("GetAtomicUTXOs", ctx, []ids.ShortID{addr}, "C", backend.getUTXOsPageSize, ids.ShortEmpty, ids.Empty).
+			Return([][]byte{}, addr, ids.Empty, nil).Once()
+		pChainMock.Mock.On("GetTxFee", ctx).Return(&info.GetTxFeeResponse{TxFee: 1000000}, nil).Once()
+
+		resp, err := backend.Call(ctx, &types.CallRequest{
+			NetworkIdentifier: pChainNetworkIdentifier,
+			Method:            mapper.CallSelectUTXOs,
+			Parameters: map[string]interface{}{
+				"address":        pChainAddr,
+				"amount":         "1",
+				"operation_type": pmapper.OpImportAvax,
+				"source_chain":   "C",
+			},
+		})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, service.ErrInvalidInput.Code, err.Code)
+		pChainMock.AssertExpectations(t)
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		resp, err := backend.Call(ctx, &types.CallRequest{
+			NetworkIdentifier: pChainNetworkIdentifier,
+			Method:            "eth_getTransactionReceipt",
+		})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, service.ErrCallInvalidMethod, err)
+	})
+}
@@ -22,6 +22,8 @@ var (
 	errUnknownTxType = errors.New("unknown tx type")
 	errUndecodableTx = errors.New("undecodable transaction")
 	errNoTxGiven     = errors.New("no transaction was given")
+	errFeeRequired   = errors.New("fee must be supplied in offline mode")
+	errFeeTooLow     = errors.New("fee is lower than the fee required by the node")
 )
 
 func (b *Backend) ConstructionDerive(
@@ -57,15 +59,14 @@ func (b *Backend) ConstructionMetadata(
 		return nil, service.WrapError(service.ErrInvalidInput, err)
 	}
 
-	var suggestedFee *types.Amount
 	var metadata *pmapper.Metadata
 	switch opMetadata.Type {
 	case pmapper.OpImportAvax:
-		metadata, suggestedFee, err = b.buildImportMetadata(ctx, req.Options)
+		metadata, err = b.buildImportMetadata(ctx, req.Options)
 	case pmapper.OpExportAvax:
-		metadata, suggestedFee, err = b.buildExportMetadata(ctx, req.Options)
+		metadata, err = b.buildExportMetadata(ctx, req.Options)
 	case pmapper.OpAddValidator, pmapper.OpAddDelegator:
-		metadata, suggestedFee, err = b.buildStakingMetadata(req.Options)
+		metadata, err = b.buildStakingMetadata(req.Options)
 	default:
 		return nil, service.WrapError(
 			service.ErrInternalError,
@@ -76,13 +77,22 @@ func (b *Backend) ConstructionMetadata(
 		return nil, service.WrapError(service.ErrInternalError, err)
 	}
 
-	networkID, err := b.pClient.GetNetworkID(ctx)
+	fee, err := b.getTxFee(ctx, opMetadata.Type, req.Options)
+	if errors.Is(err, errFeeRequired) || errors.Is(err, errFeeTooLow) {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+	metadata.Fee = fee
+
+	networkID, err := b.getNetworkID(ctx)
 	if err != nil {
 		return nil, service.WrapError(service.ErrInvalidInput, err)
 	}
 	metadata.NetworkID = networkID
 
-	pChainID, err := b.pClient.GetBlockchainID(ctx, mapper.PChainNetworkIdentifier)
+	pChainID, err := b.getBlockchainID(ctx, mapper.PChainNetworkIdentifier)
 	if err != nil {
 		return nil, service.WrapError(service.ErrInvalidInput, err)
 	}
@@ -96,47 +106,37 @@ func (b *Backend) ConstructionMetadata(
 
 	return &types.ConstructionMetadataResponse{
 		Metadata:     metadataMap,
-		SuggestedFee: []*types.Amount{suggestedFee},
+		SuggestedFee: []*types.Amount{mapper.AtomicAvaxAmount(new(big.Int).SetUint64(fee))},
 	}, nil
 }
 
-func (b *Backend) buildImportMetadata(ctx context.Context, options map[string]interface{}) (*pmapper.Metadata, *types.Amount, error) {
+func (b *Backend) buildImportMetadata(ctx context.Context, options map[string]interface{}) (*pmapper.Metadata, error) {
 	var preprocessOptions pmapper.ImportExportOptions
 	if err := mapper.UnmarshalJSONMap(options, &preprocessOptions); err != nil {
-		return nil, nil, err
-	}
-
-	sourceChainID, err := b.pClient.GetBlockchainID(ctx, preprocessOptions.SourceChain)
-	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	suggestedFee, err := b.getBaseTxFee(ctx)
+	sourceChainID, err := b.getBlockchainID(ctx, preprocessOptions.SourceChain)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	importMetadata := &pmapper.ImportMetadata{
 		SourceChainID: sourceChainID,
 	}
 
-	return &pmapper.Metadata{ImportMetadata: importMetadata}, suggestedFee, nil
+	return &pmapper.Metadata{ImportMetadata: importMetadata}, nil
 }
 
-func (b *Backend) buildExportMetadata(ctx context.Context, options map[string]interface{}) (*pmapper.Metadata, *types.Amount, error) {
+func (b *Backend) buildExportMetadata(ctx context.Context, options map[string]interface{}) (*pmapper.Metadata, error) {
 	var preprocessOptions pmapper.ImportExportOptions
 	if err := mapper.UnmarshalJSONMap(options, &preprocessOptions); err != nil {
-		return nil, nil, err
-	}
-
-	destinationChainID, err := b.pClient.GetBlockchainID(ctx, preprocessOptions.DestinationChain)
-	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	suggestedFee, err := b.getBaseTxFee(ctx)
+	destinationChainID, err := b.getBlockchainID(ctx, preprocessOptions.DestinationChain)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	exportMetadata := &pmapper.ExportMetadata{
@@ -144,24 +144,70 @@ func (b *Backend) buildExportMetadata(ctx context.Context, options map[string]in
 		DestinationChainID: destinationChainID,
 	}
 
-	return &pmapper.Metadata{ExportMetadata: exportMetadata}, suggestedFee, nil
+	return &pmapper.Metadata{ExportMetadata: exportMetadata}, nil
 }
 
-func (b *Backend) getBaseTxFee(ctx context.Context) (*types.Amount, error) {
-	fees, err := b.pClient.GetTxFee(ctx)
-	if err != nil {
-		return nil, err
+// getTxFee returns the fee a tx of type opType must burn. Online, the node is
+// always asked for it and a fee supplied in the options may only raise it.
+// Offline, the supplied fee is used as is.
+func (b *Backend) getTxFee(ctx context.Context, opType string, options map[string]interface{}) (uint64, error) {
+	var feeOptions pmapper.FeeOptions
+	if err := mapper.UnmarshalJSONMap(options, &feeOptions); err != nil {
+		return 0, err
+	}
+
+	if b.offline {
+		switch {
+		case feeOptions.Fee != nil:
+			return *feeOptions.Fee, nil
+		case pmapper.BurnsTxFee(opType):
+			return 0, errFeeRequired
+		default:
+			return 0, nil
+		}
+	}
+
+	var fee uint64
+	if pmapper.BurnsTxFee(opType) {
+		fees, err := b.pClient.GetTxFee(ctx)
+		if err != nil {
+			return 0, err
+		}
+		fee, err = pmapper.TxFee(opType, fees)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if feeOptions.Fee == nil {
+		return fee, nil
+	}
+	if *feeOptions.Fee < fee {
+		return 0, fmt.Errorf("%w: %d nAVAX supplied, %d nAVAX required", errFeeTooLow, *feeOptions.Fee, fee)
+	}
+	return *feeOptions.Fee, nil
+}
+
+// getNetworkID returns the configured network id, or asks the node if there is none
+func (b *Backend) getNetworkID(ctx context.Context) (uint32, error) {
+	if b.networkConstants != nil {
+		return b.networkConstants.NetworkID, nil
 	}
+	return b.pClient.GetNetworkID(ctx)
+}
 
-	feeAmount := new(big.Int).SetUint64(uint64(fees.TxFee))
-	suggestedFee := mapper.AtomicAvaxAmount(feeAmount)
-	return suggestedFee, nil
+// getBlockchainID returns the configured id of chain, or asks the node if there is none
+func (b *Backend) getBlockchainID(ctx context.Context, chain string) (ids.ID, error) {
+	if chainID, ok := b.networkConstants.BlockchainID(chain); ok {
+		return chainID, nil
+	}
+	return b.pClient.GetBlockchainID(ctx, chain)
 }
 
-func (b *Backend) buildStakingMetadata(options map[string]interface{}) (*pmapper.Metadata, *types.Amount, error) {
+func (b *Backend) buildStakingMetadata(options map[string]interface{}) (*pmapper.Metadata, error) {
 	var preprocessOptions pmapper.StakingOptions
 	if err := mapper.UnmarshalJSONMap(options, &preprocessOptions); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	stakingMetadata := &pmapper.StakingMetadata{
@@ -175,9 +221,7 @@ func (b *Backend) buildStakingMetadata(options map[string]interface{}) (*pmapper
 		Shares:          preprocessOptions.Shares,
 	}
 
-	zeroAvax := mapper.AtomicAvaxAmount(big.NewInt(0))
-
-	return &pmapper.Metadata{StakingMetadata: stakingMetadata}, zeroAvax, nil
+	return &pmapper.Metadata{StakingMetadata: stakingMetadata}, nil
 }
 
 func (b *Backend) ConstructionPayloads(
@@ -309,7 +353,13 @@ func (b *Backend) ConstructionSubmit(
 		return nil, service.WrapError(service.ErrInvalidInput, err)
 	}
 
-	return common.SubmitTx(ctx, b, rosettaTx)
+	resp, terr := common.SubmitTx(ctx, b, rosettaTx)
+	if terr != nil {
+		return nil, terr
+	}
+
+	b.mempool.TrackSubmittedTx(ctx, b, req, resp.TransactionIdentifier)
+	return resp, nil
 }
 
 // Defining IssueTx here without rpc.Options... to be able to use it with common.SubmitTx
@@ -10,15 +10,20 @@ import (
 
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	ajson "github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ava-labs/avalanche-rosetta/mapper"
+	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
 	mocks "github.com/ava-labs/avalanche-rosetta/mocks/client"
 	"github.com/ava-labs/avalanche-rosetta/service"
 	"github.com/ava-labs/avalanche-rosetta/service/backend/common"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/submission"
 )
 
 var (
@@ -70,7 +75,7 @@ func TestConstructionDerive(t *testing.T) {
 	pChainMock := &mocks.PChainClient{}
 	ctx := context.Background()
 	pChainMock.Mock.On("GetNetworkID", ctx).Return(uint32(5), nil)
-	backend := NewBackend(pChainMock, nil, ids.Empty, nil)
+	backend := NewBackend(pChainMock, nil, ids.Empty, nil, nil, false, nil)
 
 	t.Run("p-chain address", func(t *testing.T) {
 		src := "02e0d4392cfa224d4be19db416b3cf62e90fb2b7015e7b62a95c8cb490514943f6"
@@ -93,6 +98,36 @@ func TestConstructionDerive(t *testing.T) {
 			resp.AccountIdentifier.Address,
 		)
 	})
+
+	t.Run("all address formats", func(t *testing.T) {
+		src := "02e0d4392cfa224d4be19db416b3cf62e90fb2b7015e7b62a95c8cb490514943f6"
+		b, _ := hex.DecodeString(src)
+
+		resp, err := backend.ConstructionDerive(
+			context.Background(),
+			&types.ConstructionDeriveRequest{
+				NetworkIdentifier: pChainNetworkIdentifier,
+				PublicKey: &types.PublicKey{
+					Bytes:     b,
+					CurveType: types.Secp256k1,
+				},
+				Metadata: map[string]interface{}{
+					mapper.MetaAddressFormat: mapper.AddressFormatAll,
+				},
+			},
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, "P-fuji15f9g0h5xkr5cp47n6u3qxj6yjtzzzrdr23a3tl", resp.AccountIdentifier.Address)
+		assert.Equal(t, map[string]interface{}{
+			mapper.MetaAddressFormat: mapper.AddressFormatAll,
+			mapper.MetaAddresses: map[string]string{
+				mapper.AddressFormatHex:        "0xE31ed268b1F74EA12a68cA1677DEE79745B7A105",
+				mapper.CChainNetworkIdentifier: "C-fuji15f9g0h5xkr5cp47n6u3qxj6yjtzzzrdr23a3tl",
+				mapper.PChainNetworkIdentifier: "P-fuji15f9g0h5xkr5cp47n6u3qxj6yjtzzzrdr23a3tl",
+				mapper.XChainNetworkIdentifier: "X-fuji15f9g0h5xkr5cp47n6u3qxj6yjtzzzrdr23a3tl",
+			},
+		}, resp.Metadata)
+	})
 }
 
 func TestExportTxConstruction(t *testing.T) {
@@ -142,6 +177,7 @@ func TestExportTxConstruction(t *testing.T) {
 		"destination_chain":    "C",
 		"destination_chain_id": cChainID.String(),
 		"blockchain_id":        pChainID.String(),
+		"fee":                  float64(txFee),
 	}
 
 	signers := []*types.AccountIdentifier{pAccountIdentifier}
@@ -178,7 +214,8 @@ func TestExportTxConstruction(t *testing.T) {
 
 	ctx := context.Background()
 	clientMock := &mocks.PChainClient{}
-	backend := NewBackend(clientMock, nil, avaxAssetID, pChainNetworkIdentifier)
+	submissions := submission.NewTracker(nil, clientMock, false)
+	backend := NewBackend(clientMock, nil, avaxAssetID, pChainNetworkIdentifier, nil, false, submissions)
 
 	t.Run("preprocess endpoint", func(t *testing.T) {
 		resp, err := backend.ConstructionPreprocess(
@@ -214,6 +251,63 @@ func TestExportTxConstruction(t *testing.T) {
 		clientMock.AssertExpectations(t)
 	})
 
+	t.Run("metadata endpoint without node access", func(t *testing.T) {
+		offlineClientMock := &mocks.PChainClient{}
+		offlineBackend := NewBackend(offlineClientMock, nil, avaxAssetID, pChainNetworkIdentifier, pmapper.FujiConstants, true, nil)
+
+		options := map[string]interface{}{"fee": float64(txFee)}
+		for k, v := range metadataOptions {
+			options[k] = v
+		}
+
+		resp, err := offlineBackend.ConstructionMetadata(
+			ctx,
+			&types.ConstructionMetadataRequest{
+				NetworkIdentifier: pChainNetworkIdentifier,
+				Options:           options,
+			},
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, payloadsMetadata, resp.Metadata)
+		assert.Equal(t, []*types.Amount{mapper.AtomicAvaxAmount(big.NewInt(int64(txFee)))}, resp.SuggestedFee)
+
+		offlineClientMock.AssertExpectations(t)
+	})
+
+	t.Run("metadata endpoint rejects a fee below the node fee", func(t *testing.T) {
+		options := map[string]interface{}{"fee": float64(txFee - 1)}
+		for k, v := range metadataOptions {
+			options[k] = v
+		}
+
+		resp, err := backend.ConstructionMetadata(
+			ctx,
+			&types.ConstructionMetadataRequest{
+				NetworkIdentifier: pChainNetworkIdentifier,
+				Options:           options,
+			},
+		)
+		assert.Nil(t, resp)
+		assert.Equal(t, service.ErrInvalidInput.Code, err.Code)
+	})
+
+	t.Run("metadata endpoint without node access requires a fee", func(t *testing.T) {
+		offlineClientMock := &mocks.PChainClient{}
+		offlineBackend := NewBackend(offlineClientMock, nil, avaxAssetID, pChainNetworkIdentifier, pmapper.FujiConstants, true, nil)
+
+		resp, err := offlineBackend.ConstructionMetadata(
+			ctx,
+			&types.ConstructionMetadataRequest{
+				NetworkIdentifier: pChainNetworkIdentifier,
+				Options:           metadataOptions,
+			},
+		)
+		assert.Nil(t, resp)
+		assert.Equal(t, service.ErrInvalidInput.Code, err.Code)
+
+		offlineClientMock.AssertExpectations(t)
+	})
+
 	t.Run("payloads endpoint", func(t *testing.T) {
 		resp, err := backend.ConstructionPayloads(
 			ctx,
@@ -230,6 +324,26 @@ func TestExportTxConstruction(t *testing.T) {
 		clientMock.AssertExpectations(t)
 	})
 
+	t.Run("payloads endpoint rejects under-funded tx", func(t *testing.T) {
+		metadata := map[string]interface{}{"fee": float64(txFee + 1)}
+		for k, v := range payloadsMetadata {
+			if k != "fee" {
+				metadata[k] = v
+			}
+		}
+
+		resp, err := backend.ConstructionPayloads(
+			ctx,
+			&types.ConstructionPayloadsRequest{
+				NetworkIdentifier: pChainNetworkIdentifier,
+				Operations:        exportOperations,
+				Metadata:          metadata,
+			},
+		)
+		assert.Nil(t, resp)
+		assert.Equal(t, service.ErrInvalidInput.Code, err.Code)
+	})
+
 	t.Run("parse endpoint (unsigned)", func(t *testing.T) {
 		resp, err := backend.ConstructionParse(
 			ctx,
@@ -260,6 +374,55 @@ func TestExportTxConstruction(t *testing.T) {
 		assert.Equal(t, wrappedSignedExportTx, resp.SignedTransaction)
 	})
 
+	t.Run("combine endpoint rejects mismatching signatures", func(t *testing.T) {
+		combine := func(signature *types.Signature) *types.Error {
+			resp, err := backend.ConstructionCombine(
+				ctx,
+				&types.ConstructionCombineRequest{
+					NetworkIdentifier:   pChainNetworkIdentifier,
+					UnsignedTransaction: wrappedUnsignedExportTx,
+					Signatures:          []*types.Signature{signature},
+				},
+			)
+			assert.Nil(t, resp)
+			return err
+		}
+
+		// signed by another key
+		otherKey, _ := (&crypto.FactorySECP256K1R{}).NewPrivateKey()
+		otherSignature, _ := otherKey.SignHash(unsignedExportTxHash)
+		err := combine(&types.Signature{
+			SigningPayload: signatures[0].SigningPayload,
+			SignatureType:  types.EcdsaRecovery,
+			Bytes:          otherSignature,
+		})
+		assert.Equal(t, service.ErrInvalidSignature.Code, err.Code)
+
+		// signing payload of an account that is not a signer of the tx
+		err = combine(&types.Signature{
+			SigningPayload: &types.SigningPayload{
+				AccountIdentifier: stakeRewardAccount,
+				Bytes:             unsignedExportTxHash,
+				SignatureType:     types.EcdsaRecovery,
+			},
+			SignatureType: types.EcdsaRecovery,
+			Bytes:         signedExportTxSignature,
+		})
+		assert.Equal(t, service.ErrInvalidSignature.Code, err.Code)
+
+		// signing payload of another transaction
+		err = combine(&types.Signature{
+			SigningPayload: &types.SigningPayload{
+				AccountIdentifier: pAccountIdentifier,
+				Bytes:             make([]byte, 32),
+				SignatureType:     types.EcdsaRecovery,
+			},
+			SignatureType: types.EcdsaRecovery,
+			Bytes:         signedExportTxSignature,
+		})
+		assert.Equal(t, service.ErrInvalidSignature.Code, err.Code)
+	})
+
 	t.Run("parse endpoint (signed)", func(t *testing.T) {
 		resp, err := backend.ConstructionParse(
 			ctx,
@@ -303,17 +466,53 @@ func TestExportTxConstruction(t *testing.T) {
 
 		clientMock.AssertExpectations(t)
 	})
+
+	t.Run("submitted tx is in mempool until committed", func(t *testing.T) {
+		txId, _ := ids.FromString(signedExportTxHash)
+		mempoolTxReq := &types.MempoolTransactionRequest{
+			NetworkIdentifier:     pChainNetworkIdentifier,
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: signedExportTxHash},
+		}
+
+		// The service tracks every submission
+		submissions.TrackPChainTx(signedExportTxHash)
+		clientMock.On("GetTxStatus", ctx, txId).Return(&platformvm.GetTxStatusResponse{Status: status.Processing}, nil).Once()
+		submissions.Refresh(ctx)
+
+		mempoolResp, apiErr := backend.Mempool(ctx, &types.NetworkRequest{NetworkIdentifier: pChainNetworkIdentifier})
+		assert.Nil(t, apiErr)
+		assert.Equal(t, []*types.TransactionIdentifier{{Hash: signedExportTxHash}}, mempoolResp.TransactionIdentifiers)
+
+		txResp, apiErr := backend.MempoolTransaction(ctx, mempoolTxReq)
+		assert.Nil(t, apiErr)
+		assert.Equal(t, signedExportTxHash, txResp.Transaction.TransactionIdentifier.Hash)
+		assert.Equal(t, exportOperations, txResp.Transaction.Operations)
+
+		clientMock.On("GetTxStatus", ctx, txId).Return(&platformvm.GetTxStatusResponse{Status: status.Committed}, nil).Once()
+		submissions.Refresh(ctx)
+
+		mempoolResp, apiErr = backend.Mempool(ctx, &types.NetworkRequest{NetworkIdentifier: pChainNetworkIdentifier})
+		assert.Nil(t, apiErr)
+		assert.Empty(t, mempoolResp.TransactionIdentifiers)
+
+		_, apiErr = backend.MempoolTransaction(ctx, mempoolTxReq)
+		assert.Equal(t, service.ErrTransactionNotFound, apiErr)
+
+		clientMock.AssertExpectations(t)
+	})
 }
 
 func TestImportTxConstruction(t *testing.T) {
 	opImportAvax := "IMPORT_AVAX"
+	// importSigner made the signature of the fixture
+	importSigner := &types.AccountIdentifier{Address: "C-fuji1kgl9s68w6n89rs66060qjlhm3r6zj7q8age5wr"}
 
 	importOperations := []*types.Operation{
 		{
 			OperationIdentifier: &types.OperationIdentifier{Index: 0},
 			RelatedOperations:   nil,
 			Type:                opImportAvax,
-			Account:             cAccountIdentifier,
+			Account:             importSigner,
 			Amount:              mapper.AtomicAvaxAmount(big.NewInt(-1_000_000_000)),
 			CoinChange: &types.CoinChange{
 				CoinIdentifier: &types.CoinIdentifier{Identifier: coinId1},
@@ -351,9 +550,10 @@ func TestImportTxConstruction(t *testing.T) {
 		"network_id":      float64(networkID),
 		"source_chain_id": cChainID.String(),
 		"blockchain_id":   pChainID.String(),
+		"fee":             float64(txFee),
 	}
 
-	signers := []*types.AccountIdentifier{cAccountIdentifier}
+	signers := []*types.AccountIdentifier{importSigner}
 	importSigners := buildRosettaSignerJson([]string{coinId1}, signers)
 
 	unsignedImportTx := "0x000000000011000000050000000000000000000000000000000000000000000000000000000000000000000000013d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000007000000003b8b87c0000000000000000000000001000000015445cd01d75b4a06b6b41939193c0b1c5544490d00000000000000007fc93d85c6d62c5b2ac0b519c87010ea5294012d1e407030d6acd0021cac10d500000001f52a5a6dd8f1b3fe05204bdab4f6bcb5a7059f88d0443c636f6c158f838dd1a8000000003d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000005000000003b9aca000000000100000000000000004ce8b27d"
@@ -362,7 +562,7 @@ func TestImportTxConstruction(t *testing.T) {
 
 	signingPayloads := []*types.SigningPayload{
 		{
-			AccountIdentifier: cAccountIdentifier,
+			AccountIdentifier: importSigner,
 			Bytes:             unsignedImportTxHash,
 			SignatureType:     types.EcdsaRecovery,
 		},
@@ -376,7 +576,7 @@ func TestImportTxConstruction(t *testing.T) {
 
 	signatures := []*types.Signature{{
 		SigningPayload: &types.SigningPayload{
-			AccountIdentifier: cAccountIdentifier,
+			AccountIdentifier: importSigner,
 			Bytes:             unsignedImportTxHash,
 			SignatureType:     types.EcdsaRecovery,
 		},
@@ -386,7 +586,7 @@ func TestImportTxConstruction(t *testing.T) {
 
 	ctx := context.Background()
 	clientMock := &mocks.PChainClient{}
-	backend := NewBackend(clientMock, nil, avaxAssetID, pChainNetworkIdentifier)
+	backend := NewBackend(clientMock, nil, avaxAssetID, pChainNetworkIdentifier, nil, false, nil)
 
 	t.Run("preprocess endpoint", func(t *testing.T) {
 		resp, err := backend.ConstructionPreprocess(
@@ -514,6 +714,8 @@ func TestImportTxConstruction(t *testing.T) {
 }
 
 func TestAddValidatorTxConstruction(t *testing.T) {
+	// inputSigner made the signature of the fixture
+	inputSigner := &types.AccountIdentifier{Address: "P-fuji12h0uu6ectk5kw4fkkllff5ckd0j5vyhvqv038y"}
 	opAddValidator := "ADD_VALIDATOR"
 	startTime := uint64(1659592163)
 	endTime := startTime + 14*86400
@@ -524,7 +726,7 @@ func TestAddValidatorTxConstruction(t *testing.T) {
 			OperationIdentifier: &types.OperationIdentifier{Index: 0},
 			RelatedOperations:   nil,
 			Type:                opAddValidator,
-			Account:             pAccountIdentifier,
+			Account:             inputSigner,
 			Amount:              mapper.AtomicAvaxAmount(big.NewInt(-2_000_000_000_000)),
 			CoinChange: &types.CoinChange{
 				CoinIdentifier: &types.CoinIdentifier{Identifier: coinId1},
@@ -579,7 +781,7 @@ func TestAddValidatorTxConstruction(t *testing.T) {
 		"reward_addresses": []interface{}{stakeRewardAccount.Address},
 	}
 
-	signers := []*types.AccountIdentifier{pAccountIdentifier}
+	signers := []*types.AccountIdentifier{inputSigner}
 	stakeSigners := buildRosettaSignerJson([]string{coinId1}, signers)
 
 	unsignedTx := "0x00000000000c0000000500000000000000000000000000000000000000000000000000000000000000000000000000000001f52a5a6dd8f1b3fe05204bdab4f6bcb5a7059f88d0443c636f6c158f838dd1a8000000003d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000005000001d1a94a200000000001000000000000000077e1d5c6c289c49976f744749d54369d2129d7500000000062eb5de30000000062fdd2e3000001d1a94a2000000000013d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000007000001d1a94a2000000000000000000000000001000000015445cd01d75b4a06b6b41939193c0b1c5544490d0000000b00000000000000000000000000000001cf7cd358e2e882449d68c1c8889889eaf247b72000030d4000000000cfc0bbdf"
@@ -588,7 +790,7 @@ func TestAddValidatorTxConstruction(t *testing.T) {
 
 	signingPayloads := []*types.SigningPayload{
 		{
-			AccountIdentifier: pAccountIdentifier,
+			AccountIdentifier: inputSigner,
 			Bytes:             unsignedTxHash,
 			SignatureType:     types.EcdsaRecovery,
 		},
@@ -602,7 +804,7 @@ func TestAddValidatorTxConstruction(t *testing.T) {
 
 	signatures := []*types.Signature{{
 		SigningPayload: &types.SigningPayload{
-			AccountIdentifier: cAccountIdentifier,
+			AccountIdentifier: inputSigner,
 			Bytes:             unsignedTxHash,
 			SignatureType:     types.EcdsaRecovery,
 		},
@@ -612,7 +814,7 @@ func TestAddValidatorTxConstruction(t *testing.T) {
 
 	ctx := context.Background()
 	clientMock := &mocks.PChainClient{}
-	backend := NewBackend(clientMock, nil, avaxAssetID, pChainNetworkIdentifier)
+	backend := NewBackend(clientMock, nil, avaxAssetID, pChainNetworkIdentifier, nil, false, nil)
 
 	t.Run("preprocess endpoint", func(t *testing.T) {
 		resp, err := backend.ConstructionPreprocess(
@@ -738,6 +940,8 @@ func TestAddValidatorTxConstruction(t *testing.T) {
 }
 
 func TestAddDelegatorTxConstruction(t *testing.T) {
+	// inputSigner made the signature of the fixture
+	inputSigner := &types.AccountIdentifier{Address: "P-fuji12azmpe8595kyg7tup4lef4vdafwc84wf7qcj2d"}
 	opAddDelegator := "ADD_DELEGATOR"
 	startTime := uint64(1659592163)
 	endTime := startTime + 14*86400
@@ -747,7 +951,7 @@ func TestAddDelegatorTxConstruction(t *testing.T) {
 			OperationIdentifier: &types.OperationIdentifier{Index: 0},
 			RelatedOperations:   nil,
 			Type:                opAddDelegator,
-			Account:             pAccountIdentifier,
+			Account:             inputSigner,
 			Amount:              mapper.AtomicAvaxAmount(big.NewInt(-25_000_000_000)),
 			CoinChange: &types.CoinChange{
 				CoinIdentifier: &types.CoinIdentifier{Identifier: coinId1},
@@ -800,7 +1004,7 @@ func TestAddDelegatorTxConstruction(t *testing.T) {
 		"reward_addresses": []interface{}{stakeRewardAccount.Address},
 	}
 
-	signers := []*types.AccountIdentifier{pAccountIdentifier}
+	signers := []*types.AccountIdentifier{inputSigner}
 	stakeSigners := buildRosettaSignerJson([]string{coinId1}, signers)
 
 	unsignedTx := "0x00000000000e0000000500000000000000000000000000000000000000000000000000000000000000000000000000000001f52a5a6dd8f1b3fe05204bdab4f6bcb5a7059f88d0443c636f6c158f838dd1a8000000003d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa0000000500000005d21dba0000000001000000000000000077e1d5c6c289c49976f744749d54369d2129d7500000000062eb5de30000000062fdd2e300000005d21dba00000000013d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa0000000700000005d21dba00000000000000000000000001000000015445cd01d75b4a06b6b41939193c0b1c5544490d0000000b00000000000000000000000000000001cf7cd358e2e882449d68c1c8889889eaf247b72000000000d345d2b2"
@@ -809,7 +1013,7 @@ func TestAddDelegatorTxConstruction(t *testing.T) {
 
 	signingPayloads := []*types.SigningPayload{
 		{
-			AccountIdentifier: pAccountIdentifier,
+			AccountIdentifier: inputSigner,
 			Bytes:             unsignedTxHash,
 			SignatureType:     types.EcdsaRecovery,
 		},
@@ -823,7 +1027,7 @@ func TestAddDelegatorTxConstruction(t *testing.T) {
 
 	signatures := []*types.Signature{{
 		SigningPayload: &types.SigningPayload{
-			AccountIdentifier: cAccountIdentifier,
+			AccountIdentifier: inputSigner,
 			Bytes:             unsignedTxHash,
 			SignatureType:     types.EcdsaRecovery,
 		},
@@ -833,7 +1037,7 @@ func TestAddDelegatorTxConstruction(t *testing.T) {
 
 	ctx := context.Background()
 	clientMock := &mocks.PChainClient{}
-	backend := NewBackend(clientMock, nil, avaxAssetID, pChainNetworkIdentifier)
+	backend := NewBackend(clientMock, nil, avaxAssetID, pChainNetworkIdentifier, nil, false, nil)
 
 	t.Run("preprocess endpoint", func(t *testing.T) {
 		resp, err := backend.ConstructionPreprocess(
@@ -0,0 +1,20 @@
+package pchain
+
+import (
+	"context"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// Mempool implements the /mempool endpoint
+func (b *Backend) Mempool(ctx context.Context, req *types.NetworkRequest) (*types.MempoolResponse, *types.Error) {
+	return b.mempool.Mempool(ctx, req)
+}
+
+// MempoolTransaction implements the /mempool/transaction endpoint
+func (b *Backend) MempoolTransaction(
+	ctx context.Context,
+	req *types.MempoolTransactionRequest,
+) (*types.MempoolTransactionResponse, *types.Error) {
+	return b.mempool.MempoolTransaction(ctx, req)
+}
@@ -25,6 +25,7 @@ type AccountBalance struct {
 	Staked             uint64
 	LockedStakeable    uint64
 	LockedNotStakeable uint64
+	Multisig           uint64
 }
 
 type pTx struct {
@@ -84,6 +85,25 @@ func (p pTxParser) ParseTx(tx *common.RosettaTx, inputAddresses map[string]*type
 		return nil, err
 	}
 
+	// multisig owners are not part of the tx body, restore them from the signers
+	owners := make(map[string][]string)
+	for _, signer := range tx.AccountIdentifierSigners {
+		if len(signer.Owners) > 0 {
+			owners[signer.CoinIdentifier] = signer.Owners
+		}
+	}
+	for _, op := range transactions.Operations {
+		if op.CoinChange == nil || op.CoinChange.CoinIdentifier == nil {
+			continue
+		}
+		if opOwners, ok := owners[op.CoinChange.CoinIdentifier.Identifier]; ok {
+			if op.Metadata == nil {
+				op.Metadata = map[string]interface{}{}
+			}
+			op.Metadata[pmapper.MetadataOwners] = opOwners
+		}
+	}
+
 	return transactions.Operations, nil
 }
 
@@ -105,6 +125,11 @@ func (p pTxBuilder) BuildTx(operations []*types.Operation, metadataMap map[strin
 		return nil, nil, service.WrapError(service.ErrInvalidInput, err)
 	}
 
+	// reject under-funded txs before they are signed
+	if err := pmapper.CheckBurnedFee(operations, metadata.Fee); err != nil {
+		return nil, nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+
 	opType := matches[0].Operations[0].Type
 	tx, signers, err := pmapper.BuildTx(opType, matches, metadata, p.codec, p.avaxAssetID)
 	if err != nil {
@@ -0,0 +1,114 @@
+package pchain
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
+	"github.com/ava-labs/avalanche-rosetta/service"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/common"
+)
+
+// callSelectUTXOs selects the UTXOs of an address covering the requested
+// amount plus the fee of operation_type, and returns the input operations
+// spending them, followed by an output operation returning the change to the
+// same address.
+func (b *Backend) callSelectUTXOs(ctx context.Context, req *types.CallRequest) (*types.CallResponse, *types.Error) {
+	input, target, tErr := common.ParseSelectUTXOsInput(req)
+	if tErr != nil {
+		return nil, tErr
+	}
+	if len(input.OperationType) == 0 {
+		return nil, service.WrapError(service.ErrCallInvalidParams, "operation_type missing from params")
+	}
+
+	addr, err := address.ParseToID(input.Address)
+	if err != nil {
+		return nil, service.WrapError(service.ErrCallInvalidParams, err)
+	}
+
+	if pmapper.BurnsTxFee(input.OperationType) {
+		fees, err := b.pClient.GetTxFee(ctx)
+		if err != nil {
+			return nil, service.WrapError(service.ErrClientError, err)
+		}
+		fee, err := pmapper.TxFee(input.OperationType, fees)
+		if err != nil {
+			return nil, service.WrapError(service.ErrCallInvalidParams, err)
+		}
+		target, err = math.Add64(target, fee)
+		if err != nil {
+			return nil, service.WrapError(service.ErrCallInvalidParams, err)
+		}
+	}
+
+	utxoBytes, err := b.getAccountUTXOs(ctx, addr, input.SourceChain)
+	if err != nil {
+		return nil, service.WrapError(service.ErrClientError, err)
+	}
+	utxos, err := b.parseUTXOs(utxoBytes)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+
+	selected, total, err := common.SelectUTXOs(utxos, b.avaxAssetID, target, uint64(time.Now().Unix()))
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+
+	// UTXOs exported from another chain are spent as imported inputs
+	inputType := pmapper.OpTypeInput
+	if len(input.SourceChain) > 0 {
+		inputType = pmapper.OpTypeImport
+	}
+	inputMetadata, err := mapper.MarshalJSONMap(&pmapper.OperationMetadata{
+		Type:       inputType,
+		SigIndices: []uint32{0},
+	})
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+
+	account := &types.AccountIdentifier{Address: input.Address}
+	operations := make([]*types.Operation, 0, len(selected)+1)
+	for _, utxo := range selected {
+		amount := new(big.Int).SetUint64(utxo.Amount)
+		operations = append(operations, &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{Index: int64(len(operations))},
+			Type:                input.OperationType,
+			Account:             account,
+			Amount:              mapper.AtomicAvaxAmount(amount.Neg(amount)),
+			CoinChange: &types.CoinChange{
+				CoinIdentifier: &types.CoinIdentifier{Identifier: utxo.ID},
+				CoinAction:     types.CoinSpent,
+			},
+			Metadata: inputMetadata,
+		})
+	}
+
+	if total > target {
+		changeMetadata, err := mapper.MarshalJSONMap(&pmapper.OperationMetadata{
+			Type:      pmapper.OpTypeOutput,
+			Threshold: 1,
+		})
+		if err != nil {
+			return nil, service.WrapError(service.ErrInternalError, err)
+		}
+
+		operations = append(operations, &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{Index: int64(len(operations))},
+			Type:                input.OperationType,
+			Account:             account,
+			Amount:              mapper.AtomicAvaxAmount(new(big.Int).SetUint64(total - target)),
+			Metadata:            changeMetadata,
+		})
+	}
+
+	return common.SelectUTXOsResponse(operations, total, target)
+}
@@ -0,0 +1,324 @@
+package search
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
+)
+
+var (
+	txPrefix      = []byte("t")
+	hashPrefix    = []byte("h")
+	addressPrefix = []byte("a")
+	blockPrefix   = []byte("b")
+	checkpointKey = []byte("checkpoint")
+
+	// addressSeparator terminates addresses in keys so that an address is
+	// never matched as the prefix of a longer one
+	addressSeparator = []byte{0}
+)
+
+// OperationReference points at the operations of an account in a transaction
+type OperationReference struct {
+	BlockIdentifier       *types.BlockIdentifier       `json:"block_identifier"`
+	TransactionIdentifier *types.TransactionIdentifier `json:"transaction_identifier"`
+	OperationIndices      []int64                      `json:"operation_indices"`
+}
+
+// indexedBlock is the record kept for every indexed block so that its
+// transactions can be dropped if a different block is seen at its height
+type indexedBlock struct {
+	Hash         string   `json:"hash"`
+	Transactions []string `json:"transactions"`
+}
+
+// Index keeps the transactions of the blocks it is given in a local
+// database and answers /search/transactions from it. Transactions are keyed
+// by descending block index so that iteration returns the latest first.
+type Index struct {
+	lock sync.RWMutex
+	db   database.Database
+
+	maxTotalCount int64
+}
+
+// NewIndex returns a transaction index stored in db
+func NewIndex(db database.Database) *Index {
+	return &Index{db: db, maxTotalCount: DefaultMaxTotalCount}
+}
+
+// ShouldHandleRequest returns true for search requests on any network
+func (*Index) ShouldHandleRequest(req interface{}) bool {
+	_, ok := req.(*types.SearchTransactionsRequest)
+	return ok
+}
+
+// IndexBlock records the transactions of block. Blocks that were already
+// indexed are skipped, and a block replacing another one at the same
+// height evicts the transactions of the previous block.
+func (i *Index) IndexBlock(networkIdentifier *types.NetworkIdentifier, block *types.Block) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	chain := chainPrefix(networkIdentifier)
+	index := block.BlockIdentifier.Index
+
+	batch := i.db.NewBatch()
+	previous, err := i.getBlock(chain, index)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		if previous.Hash == block.BlockIdentifier.Hash {
+			return nil
+		}
+		if err := i.evictBlock(batch, chain, index, previous); err != nil {
+			return err
+		}
+	}
+
+	record := &indexedBlock{
+		Hash:         block.BlockIdentifier.Hash,
+		Transactions: make([]string, 0, len(block.Transactions)),
+	}
+	for _, tx := range block.Transactions {
+		txHash := tx.TransactionIdentifier.Hash
+		txBytes, err := json.Marshal(&types.BlockTransaction{
+			BlockIdentifier: block.BlockIdentifier,
+			Transaction:     tx,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := batch.Put(txKey(chain, index, txHash), txBytes); err != nil {
+			return err
+		}
+		if err := batch.Put(hashKey(chain, txHash), database.PackUInt64(uint64(index))); err != nil {
+			return err
+		}
+		refs := operationReferences(tx)
+		for _, address := range refs.addresses {
+			refBytes, err := json.Marshal(refs.operations[address])
+			if err != nil {
+				return err
+			}
+			if err := batch.Put(addressKey(chain, address, index, txHash), refBytes); err != nil {
+				return err
+			}
+		}
+		record.Transactions = append(record.Transactions, txHash)
+	}
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(blockKey(chain, index), recordBytes); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
+// Checkpoint returns the index of the last block walked by the Indexer, or
+// -1 if no block was walked yet
+func (i *Index) Checkpoint(networkIdentifier *types.NetworkIdentifier) (int64, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	checkpoint, err := database.GetUInt64(i.db, join(chainPrefix(networkIdentifier), checkpointKey))
+	if err == database.ErrNotFound {
+		return -1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int64(checkpoint), nil
+}
+
+// SetCheckpoint records index as the last block walked by the Indexer
+func (i *Index) SetCheckpoint(networkIdentifier *types.NetworkIdentifier, index int64) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return database.PutUInt64(i.db, join(chainPrefix(networkIdentifier), checkpointKey), uint64(index))
+}
+
+// AccountOperations returns up to limit references to the operations of
+// address, latest first
+func (i *Index) AccountOperations(
+	networkIdentifier *types.NetworkIdentifier,
+	address string,
+	limit int,
+) ([]*OperationReference, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	chain := chainPrefix(networkIdentifier)
+	prefix := addressIndexPrefix(chain, normalizeAddress(address))
+	iter := i.db.NewIteratorWithStartAndPrefix(prefix, prefix)
+	defer iter.Release()
+
+	refs := []*OperationReference{}
+	for len(refs) < limit && iter.Next() {
+		suffix := iter.Key()[len(prefix):]
+		index, err := database.ParseUInt64(suffix[:wrappers.LongLen])
+		if err != nil {
+			return nil, err
+		}
+
+		ref := &OperationReference{
+			BlockIdentifier: &types.BlockIdentifier{Index: int64(^index)},
+			TransactionIdentifier: &types.TransactionIdentifier{
+				Hash: string(suffix[wrappers.LongLen:]),
+			},
+		}
+		if err := json.Unmarshal(iter.Value(), &ref.OperationIndices); err != nil {
+			return nil, err
+		}
+
+		record, err := i.getBlock(chain, ref.BlockIdentifier.Index)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			ref.BlockIdentifier.Hash = record.Hash
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs, iter.Error()
+}
+
+func (i *Index) getBlock(chain []byte, index int64) (*indexedBlock, error) {
+	recordBytes, err := i.db.Get(blockKey(chain, index))
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	record := &indexedBlock{}
+	if err := json.Unmarshal(recordBytes, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (i *Index) getTransaction(key []byte) (*types.BlockTransaction, error) {
+	txBytes, err := i.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &types.BlockTransaction{}
+	if err := json.Unmarshal(txBytes, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (i *Index) evictBlock(batch database.Batch, chain []byte, index int64, record *indexedBlock) error {
+	for _, txHash := range record.Transactions {
+		key := txKey(chain, index, txHash)
+		tx, err := i.getTransaction(key)
+		if err != nil {
+			return err
+		}
+
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+		if err := batch.Delete(hashKey(chain, txHash)); err != nil {
+			return err
+		}
+		for _, address := range operationReferences(tx.Transaction).addresses {
+			if err := batch.Delete(addressKey(chain, address, index, txHash)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// accountOperations holds the operations of a transaction per account
+type accountOperations struct {
+	// addresses lists the normalized addresses in the order they appear
+	addresses []string
+	// operations maps each address to the indices of its operations
+	operations map[string][]int64
+}
+
+// operationReferences groups the operations of tx by account address
+func operationReferences(tx *types.Transaction) *accountOperations {
+	refs := &accountOperations{
+		addresses:  []string{},
+		operations: map[string][]int64{},
+	}
+	for _, op := range tx.Operations {
+		if op.Account == nil {
+			continue
+		}
+		address := normalizeAddress(op.Account.Address)
+		if _, ok := refs.operations[address]; !ok {
+			refs.addresses = append(refs.addresses, address)
+		}
+		refs.operations[address] = append(refs.operations[address], op.OperationIdentifier.Index)
+	}
+	return refs
+}
+
+// normalizeAddress makes hex and bech32 addresses comparable regardless of case
+func normalizeAddress(address string) string {
+	return strings.ToLower(address)
+}
+
+func chainPrefix(networkIdentifier *types.NetworkIdentifier) []byte {
+	if pmapper.IsPChain(networkIdentifier) {
+		return []byte(mapper.PChainNetworkIdentifier)
+	}
+	return []byte(mapper.CChainNetworkIdentifier)
+}
+
+// descending packs index so that higher indices sort first
+func descending(index int64) []byte {
+	return database.PackUInt64(^uint64(index))
+}
+
+func join(parts ...[]byte) []byte {
+	key := []byte{}
+	for _, part := range parts {
+		key = append(key, part...)
+	}
+	return key
+}
+
+func txKey(chain []byte, index int64, txHash string) []byte {
+	return join(chain, txPrefix, descending(index), []byte(txHash))
+}
+
+func hashKey(chain []byte, txHash string) []byte {
+	return join(chain, hashPrefix, []byte(txHash))
+}
+
+func addressKey(chain []byte, address string, index int64, txHash string) []byte {
+	return join(addressIndexPrefix(chain, address), descending(index), []byte(txHash))
+}
+
+func addressIndexPrefix(chain []byte, address string) []byte {
+	return join(chain, addressPrefix, []byte(address), addressSeparator)
+}
+
+func blockKey(chain []byte, index int64) []byte {
+	return join(chain, blockPrefix, database.PackUInt64(uint64(index)))
+}
@@ -0,0 +1,119 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanche-rosetta/service/backend/tracker"
+)
+
+// DefaultIndexerPollInterval is how often the indexer checks for new blocks
+// once it has caught up with the tip
+const DefaultIndexerPollInterval = time.Second
+
+var errBlockNotReturned = errors.New("block was not returned")
+
+// BlockFetcher returns blocks with their mapped transactions
+type BlockFetcher interface {
+	Block(ctx context.Context, request *types.BlockRequest) (*types.BlockResponse, *types.Error)
+}
+
+// Indexer walks the blocks of a chain in order and records them in an
+// Index. Progress is checkpointed after every block so that a restarted
+// indexer resumes where it stopped.
+type Indexer struct {
+	index             *Index
+	chain             tracker.Chain
+	blocks            BlockFetcher
+	networkIdentifier *types.NetworkIdentifier
+	startIndex        int64
+	pollInterval      time.Duration
+}
+
+// NewIndexer returns an indexer walking the chain of networkIdentifier from
+// startIndex. Blocks are fetched from blocks, which maps their transactions
+// the same way /block does.
+func NewIndexer(
+	index *Index,
+	chain tracker.Chain,
+	blocks BlockFetcher,
+	networkIdentifier *types.NetworkIdentifier,
+	startIndex int64,
+) *Indexer {
+	return &Indexer{
+		index:             index,
+		chain:             chain,
+		blocks:            blocks,
+		networkIdentifier: networkIdentifier,
+		startIndex:        startIndex,
+		pollInterval:      DefaultIndexerPollInterval,
+	}
+}
+
+// Run indexes blocks until ctx is cancelled
+func (i *Indexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(i.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := i.Sync(ctx); err != nil && ctx.Err() == nil {
+			zap.L().Warn("account history indexer sync failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync indexes every block between the checkpoint and the current tip
+func (i *Indexer) Sync(ctx context.Context) error {
+	checkpoint, err := i.index.Checkpoint(i.networkIdentifier)
+	if err != nil {
+		return err
+	}
+	if checkpoint < i.startIndex-1 {
+		checkpoint = i.startIndex - 1
+	}
+
+	tip, err := i.chain.Tip(ctx)
+	if err != nil {
+		return err
+	}
+
+	for index := checkpoint + 1; index <= tip.Index; index++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		blockIndex := index
+		resp, terr := i.blocks.Block(ctx, &types.BlockRequest{
+			NetworkIdentifier: i.networkIdentifier,
+			BlockIdentifier: &types.PartialBlockIdentifier{
+				Index: &blockIndex,
+			},
+		})
+		if terr != nil {
+			return fmt.Errorf("unable to fetch block %d: %s %v", index, terr.Message, terr.Details)
+		}
+		if resp.Block == nil {
+			return errBlockNotReturned
+		}
+
+		if err := i.index.IndexBlock(i.networkIdentifier, resp.Block); err != nil {
+			return err
+		}
+		if err := i.index.SetCheckpoint(i.networkIdentifier, index); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	ethtypes "github.com/ava-labs/coreth/core/types"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	mocks "github.com/ava-labs/avalanche-rosetta/mocks/client"
+	serviceMocks "github.com/ava-labs/avalanche-rosetta/mocks/service"
+	"github.com/ava-labs/avalanche-rosetta/service"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/tracker"
+)
+
+func blockRequest(index int64) interface{} {
+	return mock.MatchedBy(func(req *types.BlockRequest) bool {
+		return *req.BlockIdentifier.Index == index
+	})
+}
+
+func blockResponse(index int64) *types.BlockResponse {
+	return &types.BlockResponse{
+		Block: makeBlock(index, fmt.Sprintf("0x%02d", index),
+			makeTransfer(fmt.Sprintf("0xt%d", index), sender, receiver, mapper.StatusSuccess),
+		),
+	}
+}
+
+func TestIndexerSync(t *testing.T) {
+	ctx := context.Background()
+	clientMock := &mocks.Client{}
+	blocksMock := &serviceMocks.BlockBackend{}
+	index := NewIndex(memdb.New())
+	indexer := NewIndexer(index, tracker.NewCChain(clientMock), blocksMock, cChainNetworkIdentifier, 5)
+
+	t.Run("walks from the start block to the tip", func(t *testing.T) {
+		clientMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(&ethtypes.Header{Number: big.NewInt(7)}, nil).Once()
+		for i := int64(5); i <= 7; i++ {
+			blocksMock.On("Block", ctx, blockRequest(i)).Return(blockResponse(i), nil).Once()
+		}
+
+		assert.Nil(t, indexer.Sync(ctx))
+
+		checkpoint, err := index.Checkpoint(cChainNetworkIdentifier)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(7), checkpoint)
+		clientMock.AssertExpectations(t)
+		blocksMock.AssertExpectations(t)
+	})
+
+	t.Run("resumes from the checkpoint", func(t *testing.T) {
+		clientMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(&ethtypes.Header{Number: big.NewInt(9)}, nil).Once()
+		blocksMock.On("Block", ctx, blockRequest(8)).Return(blockResponse(8), nil).Once()
+		blocksMock.On("Block", ctx, blockRequest(9)).Return(nil, service.ErrClientError).Once()
+
+		assert.NotNil(t, indexer.Sync(ctx))
+
+		checkpoint, err := index.Checkpoint(cChainNetworkIdentifier)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(8), checkpoint)
+		blocksMock.AssertExpectations(t)
+	})
+
+	t.Run("account operations are recorded", func(t *testing.T) {
+		refs, err := index.AccountOperations(cChainNetworkIdentifier, receiver, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, []*OperationReference{
+			{
+				BlockIdentifier:       &types.BlockIdentifier{Index: 8, Hash: "0x08"},
+				TransactionIdentifier: &types.TransactionIdentifier{Hash: "0xt8"},
+				OperationIndices:      []int64{1},
+			},
+			{
+				BlockIdentifier:       &types.BlockIdentifier{Index: 7, Hash: "0x07"},
+				TransactionIdentifier: &types.TransactionIdentifier{Hash: "0xt7"},
+				OperationIndices:      []int64{1},
+			},
+		}, refs)
+	})
+}
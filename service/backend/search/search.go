@@ -0,0 +1,244 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+const (
+	defaultSearchLimit = 100
+	maxSearchLimit     = 1000
+
+	// DefaultMaxTotalCount is the number of matching transactions after which
+	// a search stops counting once its page is filled
+	DefaultMaxTotalCount = 10_000
+)
+
+var (
+	errInvalidOffset = errors.New("offset must not be negative")
+	errInvalidLimit  = errors.New("limit must be positive and at most 1000")
+
+	// errStopSearch ends the scan of candidate transactions early
+	errStopSearch = errors.New("stop search")
+)
+
+// SearchTransactions implements the /search/transactions endpoint.
+//
+// Matching transactions are counted up to the index's max total count. Past
+// it, the scan stops as soon as the page is filled and a further match is
+// found, so the total count is then a lower bound.
+func (i *Index) SearchTransactions(
+	ctx context.Context,
+	req *types.SearchTransactionsRequest,
+) (*types.SearchTransactionsResponse, *types.Error) {
+	offset := int64(0)
+	if req.Offset != nil {
+		offset = *req.Offset
+	}
+	if offset < 0 {
+		return nil, service.WrapError(service.ErrInvalidInput, errInvalidOffset)
+	}
+	limit := int64(defaultSearchLimit)
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+	if limit <= 0 || limit > maxSearchLimit {
+		return nil, service.WrapError(service.ErrInvalidInput, errInvalidLimit)
+	}
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	maxBlock := int64(math.MaxInt64)
+	if req.MaxBlock != nil {
+		maxBlock = *req.MaxBlock
+	}
+
+	transactions := []*types.BlockTransaction{}
+	count := int64(0)
+	err := i.candidates(req, maxBlock, func(tx *types.BlockTransaction) error {
+		if tx.BlockIdentifier.Index > maxBlock || !matches(req, tx.Transaction) {
+			return nil
+		}
+
+		if count >= offset && int64(len(transactions)) < limit {
+			transactions = append(transactions, tx)
+		}
+		count++
+		if count > offset+limit && count >= i.maxTotalCount {
+			return errStopSearch
+		}
+		return nil
+	})
+	if err != nil && err != errStopSearch {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+
+	resp := &types.SearchTransactionsResponse{
+		Transactions: transactions,
+		TotalCount:   count,
+	}
+	if nextOffset := offset + int64(len(transactions)); nextOffset < count {
+		resp.NextOffset = &nextOffset
+	}
+
+	return resp, nil
+}
+
+// candidates calls f with every indexed transaction that may match req, in
+// descending block order. Secondary indices are used to narrow the scan
+// when all conditions must hold.
+func (i *Index) candidates(
+	req *types.SearchTransactionsRequest,
+	maxBlock int64,
+	f func(*types.BlockTransaction) error,
+) error {
+	chain := chainPrefix(req.NetworkIdentifier)
+	isAnd := req.Operator == nil || *req.Operator == types.AND
+
+	if isAnd && req.TransactionIdentifier != nil {
+		indexBytes, err := i.db.Get(hashKey(chain, req.TransactionIdentifier.Hash))
+		if err == database.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		index, err := database.ParseUInt64(indexBytes)
+		if err != nil {
+			return err
+		}
+
+		tx, err := i.getTransaction(txKey(chain, int64(index), req.TransactionIdentifier.Hash))
+		if err != nil {
+			return err
+		}
+		return f(tx)
+	}
+
+	if isAnd && (req.Address != nil || req.AccountIdentifier != nil) {
+		address := ""
+		if req.Address != nil {
+			address = *req.Address
+		} else {
+			address = req.AccountIdentifier.Address
+		}
+
+		prefix := addressIndexPrefix(chain, normalizeAddress(address))
+		iter := i.db.NewIteratorWithStartAndPrefix(join(prefix, descending(maxBlock)), prefix)
+		defer iter.Release()
+
+		for iter.Next() {
+			// The address index key ends with the tx key suffix
+			suffix := iter.Key()[len(prefix):]
+			tx, err := i.getTransaction(join(chain, txPrefix, suffix))
+			if err != nil {
+				return err
+			}
+			if err := f(tx); err != nil {
+				return err
+			}
+		}
+		return iter.Error()
+	}
+
+	prefix := join(chain, txPrefix)
+	iter := i.db.NewIteratorWithStartAndPrefix(join(prefix, descending(maxBlock)), prefix)
+	defer iter.Release()
+
+	for iter.Next() {
+		tx := &types.BlockTransaction{}
+		if err := json.Unmarshal(iter.Value(), tx); err != nil {
+			return err
+		}
+		if err := f(tx); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// matches returns true if tx satisfies the conditions of req combined with
+// its operator. A request without conditions matches every transaction.
+func matches(req *types.SearchTransactionsRequest, tx *types.Transaction) bool {
+	conditions := []bool{}
+
+	if req.TransactionIdentifier != nil {
+		conditions = append(conditions, tx.TransactionIdentifier.Hash == req.TransactionIdentifier.Hash)
+	}
+	if req.AccountIdentifier != nil {
+		conditions = append(conditions, anyOperation(tx, func(op *types.Operation) bool {
+			return op.Account != nil &&
+				normalizeAddress(op.Account.Address) == normalizeAddress(req.AccountIdentifier.Address) &&
+				types.Hash(op.Account.SubAccount) == types.Hash(req.AccountIdentifier.SubAccount)
+		}))
+	}
+	if req.CoinIdentifier != nil {
+		conditions = append(conditions, anyOperation(tx, func(op *types.Operation) bool {
+			return op.CoinChange != nil &&
+				op.CoinChange.CoinIdentifier.Identifier == req.CoinIdentifier.Identifier
+		}))
+	}
+	if req.Currency != nil {
+		conditions = append(conditions, anyOperation(tx, func(op *types.Operation) bool {
+			return op.Amount != nil && types.Hash(op.Amount.Currency) == types.Hash(req.Currency)
+		}))
+	}
+	if req.Status != nil {
+		conditions = append(conditions, anyOperation(tx, func(op *types.Operation) bool {
+			return op.Status != nil && *op.Status == *req.Status
+		}))
+	}
+	if req.Type != nil {
+		conditions = append(conditions, anyOperation(tx, func(op *types.Operation) bool {
+			return op.Type == *req.Type
+		}))
+	}
+	if req.Address != nil {
+		conditions = append(conditions, anyOperation(tx, func(op *types.Operation) bool {
+			return op.Account != nil && normalizeAddress(op.Account.Address) == normalizeAddress(*req.Address)
+		}))
+	}
+	if req.Success != nil {
+		conditions = append(conditions, isSuccessful(tx) == *req.Success)
+	}
+
+	if len(conditions) == 0 {
+		return true
+	}
+
+	isOr := req.Operator != nil && *req.Operator == types.OR
+	for _, condition := range conditions {
+		if isOr && condition {
+			return true
+		}
+		if !isOr && !condition {
+			return false
+		}
+	}
+	return !isOr
+}
+
+func anyOperation(tx *types.Transaction, f func(*types.Operation) bool) bool {
+	for _, op := range tx.Operations {
+		if f(op) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSuccessful returns true if none of the operations of tx failed
+func isSuccessful(tx *types.Transaction) bool {
+	return !anyOperation(tx, func(op *types.Operation) bool {
+		return op.Status != nil && *op.Status == mapper.StatusFailure
+	})
+}
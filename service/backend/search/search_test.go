@@ -0,0 +1,198 @@
+package search
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+var (
+	cChainNetworkIdentifier = &types.NetworkIdentifier{
+		Network: mapper.FujiNetwork,
+	}
+	pChainNetworkIdentifier = &types.NetworkIdentifier{
+		Network: mapper.FujiNetwork,
+		SubNetworkIdentifier: &types.SubNetworkIdentifier{
+			Network: mapper.PChainNetworkIdentifier,
+		},
+	}
+
+	sender   = "0x57B414a0332B5CF8EA3d7D3C4f0E6DfD0a0CAf69"
+	receiver = "0xd5A0b1b3A09bA3C3e6B4a0A4E7Bb1E4bE5B73aC8"
+)
+
+func makeTransfer(hash string, from string, to string, status string) *types.Transaction {
+	return &types.Transaction{
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: hash},
+		Operations: []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                mapper.OpFee,
+				Status:              types.String(mapper.StatusSuccess),
+				Account:             &types.AccountIdentifier{Address: from},
+				Amount:              mapper.AvaxAmount(big.NewInt(1)),
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				Type:                mapper.OpCall,
+				Status:              types.String(status),
+				Account:             &types.AccountIdentifier{Address: to},
+				Amount:              mapper.AvaxAmount(big.NewInt(1)),
+			},
+		},
+	}
+}
+
+func makeBlock(index int64, hash string, txs ...*types.Transaction) *types.Block {
+	return &types.Block{
+		BlockIdentifier: &types.BlockIdentifier{Index: index, Hash: hash},
+		Transactions:    txs,
+	}
+}
+
+func search(t *testing.T, index *Index, req *types.SearchTransactionsRequest) *types.SearchTransactionsResponse {
+	if req.NetworkIdentifier == nil {
+		req.NetworkIdentifier = cChainNetworkIdentifier
+	}
+	resp, terr := index.SearchTransactions(context.Background(), req)
+	assert.Nil(t, terr)
+	return resp
+}
+
+func hashes(resp *types.SearchTransactionsResponse) []string {
+	result := []string{}
+	for _, tx := range resp.Transactions {
+		result = append(result, tx.Transaction.TransactionIdentifier.Hash)
+	}
+	return result
+}
+
+func TestSearchTransactions(t *testing.T) {
+	index := NewIndex(memdb.New())
+
+	assert.Nil(t, index.IndexBlock(cChainNetworkIdentifier, makeBlock(1, "0x01",
+		makeTransfer("0xa1", sender, receiver, mapper.StatusSuccess),
+	)))
+	assert.Nil(t, index.IndexBlock(cChainNetworkIdentifier, makeBlock(2, "0x02",
+		makeTransfer("0xa2", receiver, sender, mapper.StatusFailure),
+		makeTransfer("0xa3", sender, sender, mapper.StatusSuccess),
+	)))
+	assert.Nil(t, index.IndexBlock(pChainNetworkIdentifier, makeBlock(1, "p1",
+		makeTransfer("p-tx", "P-fuji1xyz", "P-fuji1abc", mapper.StatusSuccess),
+	)))
+
+	t.Run("all transactions latest first", func(t *testing.T) {
+		resp := search(t, index, &types.SearchTransactionsRequest{})
+		assert.Equal(t, int64(3), resp.TotalCount)
+		assert.Nil(t, resp.NextOffset)
+		assert.Equal(t, int64(2), resp.Transactions[0].BlockIdentifier.Index)
+		assert.ElementsMatch(t, []string{"0xa1", "0xa2", "0xa3"}, hashes(resp))
+	})
+
+	t.Run("by transaction hash", func(t *testing.T) {
+		resp := search(t, index, &types.SearchTransactionsRequest{
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: "0xa2"},
+		})
+		assert.Equal(t, []string{"0xa2"}, hashes(resp))
+		assert.Equal(t, "0x02", resp.Transactions[0].BlockIdentifier.Hash)
+	})
+
+	t.Run("by address is case insensitive", func(t *testing.T) {
+		resp := search(t, index, &types.SearchTransactionsRequest{
+			Address:  types.String("0xd5a0b1b3a09ba3c3e6b4a0a4e7bb1e4be5b73ac8"),
+			MaxBlock: types.Int64(1),
+		})
+		assert.Equal(t, []string{"0xa1"}, hashes(resp))
+	})
+
+	t.Run("by account and success", func(t *testing.T) {
+		resp := search(t, index, &types.SearchTransactionsRequest{
+			AccountIdentifier: &types.AccountIdentifier{Address: receiver},
+			Success:           types.Bool(false),
+		})
+		assert.Equal(t, []string{"0xa2"}, hashes(resp))
+	})
+
+	t.Run("by status or type", func(t *testing.T) {
+		operator := types.OR
+		resp := search(t, index, &types.SearchTransactionsRequest{
+			Operator: &operator,
+			Status:   types.String(mapper.StatusFailure),
+			Type:     types.String(mapper.OpExport),
+		})
+		assert.Equal(t, []string{"0xa2"}, hashes(resp))
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		resp := search(t, index, &types.SearchTransactionsRequest{
+			Currency: mapper.AvaxCurrency,
+			Limit:    types.Int64(2),
+		})
+		assert.Equal(t, int64(3), resp.TotalCount)
+		assert.Len(t, resp.Transactions, 2)
+		assert.Equal(t, int64(2), *resp.NextOffset)
+
+		resp = search(t, index, &types.SearchTransactionsRequest{
+			Currency: mapper.AvaxCurrency,
+			Offset:   resp.NextOffset,
+			Limit:    types.Int64(2),
+		})
+		assert.Equal(t, []string{"0xa1"}, hashes(resp))
+		assert.Nil(t, resp.NextOffset)
+	})
+
+	t.Run("invalid pagination", func(t *testing.T) {
+		for _, req := range []*types.SearchTransactionsRequest{
+			{Offset: types.Int64(-1)},
+			{Limit: types.Int64(0)},
+			{Limit: types.Int64(maxSearchLimit + 1)},
+		} {
+			req.NetworkIdentifier = cChainNetworkIdentifier
+			resp, terr := index.SearchTransactions(context.Background(), req)
+			assert.Nil(t, resp)
+			assert.Equal(t, service.ErrInvalidInput.Code, terr.Code)
+		}
+	})
+
+	t.Run("count is capped once the page is filled", func(t *testing.T) {
+		index.maxTotalCount = 1
+		defer func() { index.maxTotalCount = DefaultMaxTotalCount }()
+
+		resp := search(t, index, &types.SearchTransactionsRequest{
+			Currency: mapper.AvaxCurrency,
+			Limit:    types.Int64(1),
+		})
+		assert.Equal(t, int64(2), resp.TotalCount)
+		assert.Len(t, resp.Transactions, 1)
+		assert.Equal(t, int64(1), *resp.NextOffset)
+	})
+
+	t.Run("p-chain is indexed separately", func(t *testing.T) {
+		resp := search(t, index, &types.SearchTransactionsRequest{
+			NetworkIdentifier: pChainNetworkIdentifier,
+			Address:           types.String("P-fuji1abc"),
+		})
+		assert.Equal(t, []string{"p-tx"}, hashes(resp))
+	})
+
+	t.Run("replaced block evicts its transactions", func(t *testing.T) {
+		assert.Nil(t, index.IndexBlock(cChainNetworkIdentifier, makeBlock(2, "0x02b",
+			makeTransfer("0xb1", receiver, receiver, mapper.StatusSuccess),
+		)))
+
+		resp := search(t, index, &types.SearchTransactionsRequest{})
+		assert.ElementsMatch(t, []string{"0xa1", "0xb1"}, hashes(resp))
+
+		resp = search(t, index, &types.SearchTransactionsRequest{
+			Address: types.String(sender),
+		})
+		assert.Equal(t, []string{"0xa1"}, hashes(resp))
+	})
+}
@@ -0,0 +1,378 @@
+package submission
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	ethtypes "github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/ava-labs/coreth/plugin/evm"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+const (
+	// KindEvm identifies C-chain EVM transactions
+	KindEvm = "evm"
+	// KindAtomic identifies C-chain atomic transactions
+	KindAtomic = "atomic"
+	// KindPChain identifies P-chain transactions
+	KindPChain = "pchain"
+
+	// StatusPending is reported until the node decides the transaction
+	StatusPending = "pending"
+	// StatusAccepted is reported once the transaction is included in a block
+	StatusAccepted = "accepted"
+	// StatusReverted is reported for included EVM transactions that failed
+	// and for aborted P-chain proposals
+	StatusReverted = "reverted"
+	// StatusDropped is reported once the node no longer knows the transaction
+	StatusDropped = "dropped"
+
+	// DefaultPollInterval is how often pending submissions are checked
+	DefaultPollInterval = 2 * time.Second
+	// DefaultRetention is how long decided submissions are kept
+	DefaultRetention = 24 * time.Hour
+	// DefaultPendingTTL is how long atomic and P-chain submissions may stay
+	// unknown to the node or processing before they are reported as dropped
+	DefaultPendingTTL = 10 * time.Minute
+	// MaxConcurrentRefreshes is how many pending submissions are checked at
+	// once
+	MaxConcurrentRefreshes = 8
+	// MaxRebroadcasts is how many times a dropped EVM transaction is sent
+	// again before it is reported as dropped
+	MaxRebroadcasts = 3
+)
+
+// Submission is the status of a transaction submitted through
+// /construction/submit
+type Submission struct {
+	Hash            string                 `json:"hash"`
+	Kind            string                 `json:"kind"`
+	Status          string                 `json:"status"`
+	BlockIdentifier *types.BlockIdentifier `json:"block_identifier,omitempty"`
+	Rebroadcasts    int                    `json:"rebroadcasts"`
+	SubmittedAt     int64                  `json:"submitted_at"`
+	UpdatedAt       int64                  `json:"updated_at"`
+
+	// tx is kept for EVM transactions so that they can be rebroadcast
+	tx *ethtypes.Transaction
+}
+
+// CallMethods are the call methods answered by the tracker on any network
+var CallMethods = []string{mapper.CallGetSubmittedTransactionStatus}
+
+// GetSubmissionInput is the input to the call method
+// mapper.CallGetSubmittedTransactionStatus
+type GetSubmissionInput struct {
+	Hash string `json:"hash"`
+}
+
+// Tracker records the transactions submitted through this server and polls
+// the node until they are accepted, reverted or dropped. Submissions are
+// kept in memory and do not survive a restart.
+type Tracker struct {
+	lock        sync.Mutex
+	cClient     client.Client
+	pClient     client.PChainClient
+	rebroadcast bool
+	submissions map[string]*Submission
+
+	pollInterval time.Duration
+	retention    time.Duration
+	pendingTTL   time.Duration
+	now          func() time.Time
+}
+
+// NewTracker returns a submission tracker. If rebroadcast is set, EVM
+// transactions the node no longer knows are sent again up to
+// MaxRebroadcasts times.
+func NewTracker(cClient client.Client, pClient client.PChainClient, rebroadcast bool) *Tracker {
+	return &Tracker{
+		cClient:      cClient,
+		pClient:      pClient,
+		rebroadcast:  rebroadcast,
+		submissions:  map[string]*Submission{},
+		pollInterval: DefaultPollInterval,
+		retention:    DefaultRetention,
+		pendingTTL:   DefaultPendingTTL,
+		now:          time.Now,
+	}
+}
+
+// TrackEvmTx records a submitted EVM transaction
+func (t *Tracker) TrackEvmTx(tx *ethtypes.Transaction) {
+	t.track(tx.Hash().String(), KindEvm, tx)
+}
+
+// TrackAtomicTx records a submitted C-chain atomic transaction
+func (t *Tracker) TrackAtomicTx(hash string) {
+	t.track(hash, KindAtomic, nil)
+}
+
+// TrackPChainTx records a submitted P-chain transaction
+func (t *Tracker) TrackPChainTx(hash string) {
+	t.track(hash, KindPChain, nil)
+}
+
+func (t *Tracker) track(hash string, kind string, tx *ethtypes.Transaction) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now().UnixMilli()
+	t.submissions[hash] = &Submission{
+		Hash:        hash,
+		Kind:        kind,
+		Status:      StatusPending,
+		SubmittedAt: now,
+		UpdatedAt:   now,
+		tx:          tx,
+	}
+}
+
+// ShouldHandleRequest returns true for submission status calls on any network
+func (*Tracker) ShouldHandleRequest(req interface{}) bool {
+	r, ok := req.(*types.CallRequest)
+	return ok && r.Method == mapper.CallGetSubmittedTransactionStatus
+}
+
+// Call implements the /call endpoint for mapper.CallGetSubmittedTransactionStatus
+func (t *Tracker) Call(ctx context.Context, req *types.CallRequest) (*types.CallResponse, *types.Error) {
+	var input GetSubmissionInput
+	if err := types.UnmarshalMap(req.Parameters, &input); err != nil {
+		return nil, service.WrapError(service.ErrCallInvalidParams, err)
+	}
+	if len(input.Hash) == 0 {
+		return nil, service.WrapError(service.ErrCallInvalidParams, "hash missing from params")
+	}
+
+	submission, ok := t.Get(input.Hash)
+	if !ok {
+		return nil, service.ErrTransactionNotFound
+	}
+	if submission.Status == StatusPending {
+		if err := t.refresh(ctx, submission); err != nil {
+			return nil, service.WrapError(service.ErrClientError, err)
+		}
+		submission, _ = t.Get(input.Hash)
+	}
+
+	result, err := mapper.MarshalJSONMap(submission)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+
+	return &types.CallResponse{Result: result}, nil
+}
+
+// Get returns a copy of the submission recorded for hash
+func (t *Tracker) Get(hash string) (Submission, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	submission, ok := t.submissions[hash]
+	if !ok {
+		return Submission{}, false
+	}
+	return *submission, true
+}
+
+// IsPending returns whether the submission recorded for hash is still
+// pending, and whether there is one
+func (t *Tracker) IsPending(hash string) (bool, bool) {
+	submission, ok := t.Get(hash)
+	return ok && submission.Status == StatusPending, ok
+}
+
+// Run polls pending submissions until ctx is cancelled
+func (t *Tracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		t.Refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh checks the status of every pending submission, up to
+// MaxConcurrentRefreshes at once, and forgets the decided ones older than the
+// retention period
+func (t *Tracker) Refresh(ctx context.Context) {
+	t.lock.Lock()
+	pending := []Submission{}
+	expiry := t.now().Add(-t.retention).UnixMilli()
+	for hash, submission := range t.submissions {
+		switch {
+		case submission.Status == StatusPending:
+			pending = append(pending, *submission)
+		case submission.UpdatedAt < expiry:
+			delete(t.submissions, hash)
+		}
+	}
+	t.lock.Unlock()
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].SubmittedAt < pending[j].SubmittedAt
+	})
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, MaxConcurrentRefreshes)
+	for _, submission := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(submission Submission) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := t.refresh(ctx, submission); err != nil {
+				zap.L().Warn("unable to refresh status of submitted tx", zap.String("hash", submission.Hash), zap.Error(err))
+			}
+		}(submission)
+	}
+	wg.Wait()
+}
+
+// refresh asks the node for the status of submission and records it
+func (t *Tracker) refresh(ctx context.Context, submission Submission) error {
+	var err error
+	switch submission.Kind {
+	case KindEvm:
+		err = t.refreshEvmTx(ctx, &submission)
+	case KindAtomic:
+		err = t.refreshAtomicTx(ctx, &submission)
+	case KindPChain:
+		err = t.refreshPChainTx(ctx, &submission)
+	}
+	if err != nil {
+		return err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// The submission may have been submitted again while it was refreshed
+	current, ok := t.submissions[submission.Hash]
+	if !ok || current.SubmittedAt != submission.SubmittedAt {
+		return nil
+	}
+	if current.Status != submission.Status || current.Rebroadcasts != submission.Rebroadcasts {
+		submission.UpdatedAt = t.now().UnixMilli()
+	}
+	*current = submission
+	return nil
+}
+
+func (t *Tracker) refreshEvmTx(ctx context.Context, submission *Submission) error {
+	hash := ethcommon.HexToHash(submission.Hash)
+
+	receipt, err := t.cClient.TransactionReceipt(ctx, hash)
+	switch {
+	case err == nil:
+		submission.Status = StatusAccepted
+		if receipt.Status == ethtypes.ReceiptStatusFailed {
+			submission.Status = StatusReverted
+		}
+		submission.BlockIdentifier = &types.BlockIdentifier{
+			Index: receipt.BlockNumber.Int64(),
+			Hash:  receipt.BlockHash.String(),
+		}
+		return nil
+	case err != interfaces.NotFound:
+		return err
+	}
+
+	_, _, err = t.cClient.TransactionByHash(ctx, hash)
+	switch {
+	case err == nil:
+		return nil
+	case err != interfaces.NotFound:
+		return err
+	}
+
+	if !t.rebroadcast || submission.tx == nil || submission.Rebroadcasts >= MaxRebroadcasts {
+		submission.Status = StatusDropped
+		return nil
+	}
+
+	submission.Rebroadcasts++
+	if err := t.cClient.SendTransaction(ctx, submission.tx); err != nil {
+		zap.L().Warn("unable to rebroadcast dropped tx", zap.String("hash", submission.Hash), zap.Error(err))
+		submission.Status = StatusDropped
+	}
+	return nil
+}
+
+func (t *Tracker) refreshAtomicTx(ctx context.Context, submission *Submission) error {
+	txID, err := ids.FromString(submission.Hash)
+	if err != nil {
+		return err
+	}
+
+	txStatus, err := t.cClient.GetAtomicTxStatus(ctx, txID)
+	if err != nil {
+		return err
+	}
+
+	switch txStatus {
+	case evm.Accepted:
+		submission.Status = StatusAccepted
+	case evm.Dropped:
+		submission.Status = StatusDropped
+	default:
+		t.expirePending(submission)
+	}
+	return nil
+}
+
+func (t *Tracker) refreshPChainTx(ctx context.Context, submission *Submission) error {
+	txID, err := ids.FromString(submission.Hash)
+	if err != nil {
+		return err
+	}
+
+	resp, err := t.pClient.GetTxStatus(ctx, txID)
+	if err != nil {
+		return err
+	}
+
+	switch resp.Status {
+	case status.Committed:
+		submission.Status = StatusAccepted
+	case status.Aborted:
+		submission.Status = StatusReverted
+	case status.Dropped:
+		submission.Status = StatusDropped
+	default:
+		t.expirePending(submission)
+	}
+	return nil
+}
+
+// expirePending reports submission as dropped once it has been pending for
+// longer than the pending TTL, as the node may never decide a tx it lost
+// track of
+func (t *Tracker) expirePending(submission *Submission) {
+	if t.now().Add(-t.pendingTTL).UnixMilli() > submission.SubmittedAt {
+		submission.Status = StatusDropped
+	}
+}
@@ -0,0 +1,201 @@
+package submission
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	ethtypes "github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/ava-labs/coreth/plugin/evm"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	mocks "github.com/ava-labs/avalanche-rosetta/mocks/client"
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+func statusCall(hash string) *types.CallRequest {
+	return &types.CallRequest{
+		NetworkIdentifier: &types.NetworkIdentifier{Network: mapper.FujiNetwork},
+		Method:            mapper.CallGetSubmittedTransactionStatus,
+		Parameters:        map[string]interface{}{"hash": hash},
+	}
+}
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+
+	evmTx := ethtypes.NewTransaction(
+		1,
+		ethcommon.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"),
+		big.NewInt(1),
+		21000,
+		big.NewInt(25),
+		nil,
+	)
+	pTxID := ids.GenerateTestID()
+	atomicTxID := ids.GenerateTestID()
+
+	t.Run("handles only submission status calls", func(t *testing.T) {
+		tracker := NewTracker(&mocks.Client{}, &mocks.PChainClient{}, false)
+
+		assert.True(t, tracker.ShouldHandleRequest(statusCall("hash")))
+		assert.False(t, tracker.ShouldHandleRequest(&types.CallRequest{Method: "eth_getCode"}))
+		assert.False(t, tracker.ShouldHandleRequest(&types.MempoolTransactionRequest{}))
+	})
+
+	t.Run("unknown submission is not found", func(t *testing.T) {
+		tracker := NewTracker(&mocks.Client{}, &mocks.PChainClient{}, false)
+
+		_, terr := tracker.Call(ctx, statusCall("0x01"))
+		assert.Equal(t, service.ErrTransactionNotFound, terr)
+	})
+
+	t.Run("evm tx is accepted once its receipt is available", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		tracker := NewTracker(clientMock, &mocks.PChainClient{}, false)
+		tracker.TrackEvmTx(evmTx)
+
+		clientMock.On("TransactionReceipt", ctx, evmTx.Hash()).Return(nil, interfaces.NotFound).Once()
+		clientMock.On("TransactionByHash", ctx, evmTx.Hash()).Return(evmTx, true, nil).Once()
+		tracker.Refresh(ctx)
+
+		submission, ok := tracker.Get(evmTx.Hash().String())
+		assert.True(t, ok)
+		assert.Equal(t, StatusPending, submission.Status)
+
+		clientMock.On("TransactionReceipt", ctx, evmTx.Hash()).Return(&ethtypes.Receipt{
+			Status:      ethtypes.ReceiptStatusFailed,
+			BlockHash:   ethcommon.HexToHash("0x0a"),
+			BlockNumber: big.NewInt(10),
+		}, nil).Once()
+
+		resp, terr := tracker.Call(ctx, statusCall(evmTx.Hash().String()))
+		assert.Nil(t, terr)
+		assert.Equal(t, KindEvm, resp.Result["kind"])
+		assert.Equal(t, StatusReverted, resp.Result["status"])
+		assert.Equal(t, map[string]interface{}{
+			"index": float64(10),
+			"hash":  ethcommon.HexToHash("0x0a").String(),
+		}, resp.Result["block_identifier"])
+
+		// Decided submissions are not polled again
+		tracker.Refresh(ctx)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("dropped evm tx is rebroadcast", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		tracker := NewTracker(clientMock, &mocks.PChainClient{}, true)
+		tracker.TrackEvmTx(evmTx)
+
+		clientMock.On("TransactionReceipt", ctx, evmTx.Hash()).Return(nil, interfaces.NotFound)
+		clientMock.On("TransactionByHash", ctx, evmTx.Hash()).Return(nil, false, interfaces.NotFound)
+		clientMock.On("SendTransaction", ctx, evmTx).Return(nil).Times(MaxRebroadcasts)
+
+		for i := 1; i <= MaxRebroadcasts; i++ {
+			tracker.Refresh(ctx)
+
+			submission, _ := tracker.Get(evmTx.Hash().String())
+			assert.Equal(t, StatusPending, submission.Status)
+			assert.Equal(t, i, submission.Rebroadcasts)
+		}
+
+		tracker.Refresh(ctx)
+		submission, _ := tracker.Get(evmTx.Hash().String())
+		assert.Equal(t, StatusDropped, submission.Status)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("dropped evm tx is reported without rebroadcast", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		tracker := NewTracker(clientMock, &mocks.PChainClient{}, false)
+		tracker.TrackEvmTx(evmTx)
+
+		clientMock.On("TransactionReceipt", ctx, evmTx.Hash()).Return(nil, interfaces.NotFound).Once()
+		clientMock.On("TransactionByHash", ctx, evmTx.Hash()).Return(nil, false, interfaces.NotFound).Once()
+		tracker.Refresh(ctx)
+
+		submission, _ := tracker.Get(evmTx.Hash().String())
+		assert.Equal(t, StatusDropped, submission.Status)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("p-chain and atomic txs follow the node status", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		pClientMock := &mocks.PChainClient{}
+		tracker := NewTracker(clientMock, pClientMock, false)
+		tracker.TrackPChainTx(pTxID.String())
+		tracker.TrackAtomicTx(atomicTxID.String())
+
+		pClientMock.On("GetTxStatus", ctx, pTxID).Return(&platformvm.GetTxStatusResponse{Status: status.Aborted}, nil).Once()
+		clientMock.On("GetAtomicTxStatus", ctx, atomicTxID).Return(evm.Accepted, nil).Once()
+		tracker.Refresh(ctx)
+
+		submission, _ := tracker.Get(pTxID.String())
+		assert.Equal(t, KindPChain, submission.Kind)
+		assert.Equal(t, StatusReverted, submission.Status)
+
+		submission, _ = tracker.Get(atomicTxID.String())
+		assert.Equal(t, KindAtomic, submission.Kind)
+		assert.Equal(t, StatusAccepted, submission.Status)
+
+		clientMock.AssertExpectations(t)
+		pClientMock.AssertExpectations(t)
+	})
+
+	t.Run("undecided p-chain and atomic txs are dropped after the pending ttl", func(t *testing.T) {
+		clientMock := &mocks.Client{}
+		pClientMock := &mocks.PChainClient{}
+		tracker := NewTracker(clientMock, pClientMock, false)
+		now := time.Now()
+		tracker.now = func() time.Time { return now }
+		tracker.TrackPChainTx(pTxID.String())
+		tracker.TrackAtomicTx(atomicTxID.String())
+
+		pClientMock.On("GetTxStatus", ctx, pTxID).Return(&platformvm.GetTxStatusResponse{Status: status.Processing}, nil).Twice()
+		clientMock.On("GetAtomicTxStatus", ctx, atomicTxID).Return(evm.Unknown, nil).Twice()
+		tracker.Refresh(ctx)
+
+		submission, _ := tracker.Get(pTxID.String())
+		assert.Equal(t, StatusPending, submission.Status)
+		submission, _ = tracker.Get(atomicTxID.String())
+		assert.Equal(t, StatusPending, submission.Status)
+
+		now = now.Add(DefaultPendingTTL + time.Second)
+		tracker.Refresh(ctx)
+
+		submission, _ = tracker.Get(pTxID.String())
+		assert.Equal(t, StatusDropped, submission.Status)
+		submission, _ = tracker.Get(atomicTxID.String())
+		assert.Equal(t, StatusDropped, submission.Status)
+
+		clientMock.AssertExpectations(t)
+		pClientMock.AssertExpectations(t)
+	})
+
+	t.Run("decided submissions expire", func(t *testing.T) {
+		pClientMock := &mocks.PChainClient{}
+		tracker := NewTracker(&mocks.Client{}, pClientMock, false)
+		now := time.Now()
+		tracker.now = func() time.Time { return now }
+		tracker.TrackPChainTx(pTxID.String())
+
+		pClientMock.On("GetTxStatus", ctx, pTxID).Return(&platformvm.GetTxStatusResponse{Status: status.Committed}, nil).Once()
+		tracker.Refresh(ctx)
+
+		now = now.Add(DefaultRetention + time.Second)
+		tracker.Refresh(ctx)
+
+		_, ok := tracker.Get(pTxID.String())
+		assert.False(t, ok)
+		pClientMock.AssertExpectations(t)
+	})
+}
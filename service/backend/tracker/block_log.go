@@ -0,0 +1,213 @@
+package tracker
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+var (
+	eventPrefix = []byte("e")
+	blockPrefix = []byte("b")
+	headKey     = []byte("head")
+	sequenceKey = []byte("sequence")
+
+	errNoHead = errors.New("block log is empty")
+)
+
+// BlockLog is an ordered, persisted log of block events. Alongside the
+// events it keeps the canonical hash at every tracked height so a reorg
+// can be detected and unwound.
+type BlockLog struct {
+	lock sync.RWMutex
+	db   database.Database
+
+	head         *types.BlockIdentifier
+	nextSequence int64
+}
+
+// NewBlockLog loads the block log stored in db
+func NewBlockLog(db database.Database) (*BlockLog, error) {
+	l := &BlockLog{db: db}
+
+	headBytes, err := db.Get(headKey)
+	switch {
+	case err == database.ErrNotFound:
+	case err != nil:
+		return nil, err
+	default:
+		l.head = &types.BlockIdentifier{}
+		if err := json.Unmarshal(headBytes, l.head); err != nil {
+			return nil, err
+		}
+	}
+
+	nextSequence, err := database.GetUInt64(db, sequenceKey)
+	switch {
+	case err == database.ErrNotFound:
+	case err != nil:
+		return nil, err
+	default:
+		l.nextSequence = int64(nextSequence)
+	}
+
+	return l, nil
+}
+
+// Head returns the most recent block in the log, or nil if the log is empty
+func (l *BlockLog) Head() *types.BlockIdentifier {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.head
+}
+
+// MaxSequence returns the sequence of the last event, or -1 if there is none
+func (l *BlockLog) MaxSequence() int64 {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.nextSequence - 1
+}
+
+// AddBlock appends a block_added event and makes block the new head
+func (l *BlockLog) AddBlock(block *types.BlockIdentifier) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	batch := l.db.NewBatch()
+	if err := batch.Put(blockKey(block.Index), []byte(block.Hash)); err != nil {
+		return err
+	}
+	if err := l.writeEvent(batch, block, types.ADDED, block); err != nil {
+		return err
+	}
+
+	l.head = block
+	l.nextSequence++
+	return nil
+}
+
+// RemoveHead appends a block_removed event for the head block and makes its
+// parent the new head
+func (l *BlockLog) RemoveHead() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.head == nil {
+		return errNoHead
+	}
+
+	removed := l.head
+	parent, err := l.blockAt(removed.Index - 1)
+	if err != nil {
+		return err
+	}
+
+	batch := l.db.NewBatch()
+	if err := batch.Delete(blockKey(removed.Index)); err != nil {
+		return err
+	}
+	if err := l.writeEvent(batch, removed, types.REMOVED, parent); err != nil {
+		return err
+	}
+
+	l.head = parent
+	l.nextSequence++
+	return nil
+}
+
+// Events returns up to limit events starting at sequence offset
+func (l *BlockLog) Events(offset int64, limit int64) ([]*types.BlockEvent, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	events := []*types.BlockEvent{}
+	if offset < 0 || offset >= l.nextSequence {
+		return events, nil
+	}
+
+	iter := l.db.NewIteratorWithStartAndPrefix(eventKey(offset), eventPrefix)
+	defer iter.Release()
+
+	for int64(len(events)) < limit && iter.Next() {
+		event := &types.BlockEvent{}
+		if err := json.Unmarshal(iter.Value(), event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, iter.Error()
+}
+
+// blockAt returns the tracked block at index, or nil if there is none
+func (l *BlockLog) blockAt(index int64) (*types.BlockIdentifier, error) {
+	if index < 0 {
+		return nil, nil
+	}
+
+	hash, err := l.db.Get(blockKey(index))
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.BlockIdentifier{
+		Index: index,
+		Hash:  string(hash),
+	}, nil
+}
+
+// writeEvent adds an event and the updated head to batch and writes it
+func (l *BlockLog) writeEvent(
+	batch database.Batch,
+	block *types.BlockIdentifier,
+	eventType types.BlockEventType,
+	head *types.BlockIdentifier,
+) error {
+	eventBytes, err := json.Marshal(&types.BlockEvent{
+		Sequence:        l.nextSequence,
+		BlockIdentifier: block,
+		Type:            eventType,
+	})
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(eventKey(l.nextSequence), eventBytes); err != nil {
+		return err
+	}
+
+	if head == nil {
+		if err := batch.Delete(headKey); err != nil {
+			return err
+		}
+	} else {
+		headBytes, err := json.Marshal(head)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(headKey, headBytes); err != nil {
+			return err
+		}
+	}
+
+	if err := database.PutUInt64(batch, sequenceKey, uint64(l.nextSequence+1)); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
+func eventKey(sequence int64) []byte {
+	return append(append([]byte{}, eventPrefix...), database.PackUInt64(uint64(sequence))...)
+}
+
+func blockKey(index int64) []byte {
+	return append(append([]byte{}, blockPrefix...), database.PackUInt64(uint64(index))...)
+}
@@ -0,0 +1,85 @@
+package tracker
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/pchain/indexer"
+)
+
+// Chain is the view of a blockchain the tracker follows
+type Chain interface {
+	// Tip returns the identifier of the latest block
+	Tip(ctx context.Context) (*types.BlockIdentifier, error)
+	// BlockAt returns the identifier of the canonical block at index
+	BlockAt(ctx context.Context, index int64) (*types.BlockIdentifier, error)
+}
+
+type cChain struct {
+	client client.Client
+}
+
+// NewCChain returns a Chain following the C-chain tip through the evm client
+func NewCChain(client client.Client) Chain {
+	return &cChain{client: client}
+}
+
+func (c *cChain) Tip(ctx context.Context) (*types.BlockIdentifier, error) {
+	header, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.BlockIdentifier{
+		Index: header.Number.Int64(),
+		Hash:  header.Hash().String(),
+	}, nil
+}
+
+func (c *cChain) BlockAt(ctx context.Context, index int64) (*types.BlockIdentifier, error) {
+	header, err := c.client.HeaderByNumber(ctx, big.NewInt(index))
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.BlockIdentifier{
+		Index: header.Number.Int64(),
+		Hash:  header.Hash().String(),
+	}, nil
+}
+
+type pChain struct {
+	parser indexer.Parser
+}
+
+// NewPChain returns a Chain following the P-chain tip through the indexer parser
+func NewPChain(parser indexer.Parser) Chain {
+	return &pChain{parser: parser}
+}
+
+func (p *pChain) Tip(ctx context.Context) (*types.BlockIdentifier, error) {
+	block, err := p.parser.ParseCurrentBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.BlockIdentifier{
+		Index: int64(block.Height),
+		Hash:  block.BlockID.String(),
+	}, nil
+}
+
+func (p *pChain) BlockAt(ctx context.Context, index int64) (*types.BlockIdentifier, error) {
+	block, err := p.parser.ParseBlockAtIndex(ctx, uint64(index))
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.BlockIdentifier{
+		Index: int64(block.Height),
+		Hash:  block.BlockID.String(),
+	}, nil
+}
@@ -0,0 +1,160 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"go.uber.org/zap"
+
+	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+const (
+	// DefaultPollInterval is how often the chain tip is polled
+	DefaultPollInterval = time.Second
+
+	// maxEventsLimit caps the number of events returned by /events/blocks
+	maxEventsLimit = 1000
+)
+
+var errInvalidEventsRange = errors.New("offset and limit must not be negative")
+
+// Tracker follows the tip of a chain and records the blocks it sees in a
+// BlockLog. When the hash at a tracked height changes, the stale blocks are
+// unwound with block_removed events before the new ones are added.
+type Tracker struct {
+	chain             Chain
+	log               *BlockLog
+	networkIdentifier *types.NetworkIdentifier
+	pollInterval      time.Duration
+}
+
+// NewTracker returns a tracker for the chain of networkIdentifier
+func NewTracker(chain Chain, blockLog *BlockLog, networkIdentifier *types.NetworkIdentifier) *Tracker {
+	return &Tracker{
+		chain:             chain,
+		log:               blockLog,
+		networkIdentifier: networkIdentifier,
+		pollInterval:      DefaultPollInterval,
+	}
+}
+
+// ShouldHandleRequest returns true if the request targets the tracked network
+func (t *Tracker) ShouldHandleRequest(req interface{}) bool {
+	if r, ok := req.(*types.EventsBlocksRequest); ok {
+		return pmapper.IsPChain(r.NetworkIdentifier) == pmapper.IsPChain(t.networkIdentifier)
+	}
+
+	return false
+}
+
+// Run polls the chain tip until ctx is cancelled
+func (t *Tracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.Sync(ctx); err != nil && ctx.Err() == nil {
+			zap.L().Warn("block tracker sync failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync brings the block log up to date with the current chain tip
+func (t *Tracker) Sync(ctx context.Context) error {
+	tip, err := t.chain.Tip(ctx)
+	if err != nil {
+		return err
+	}
+
+	head := t.log.Head()
+	if head != nil && head.Index > tip.Index {
+		// The node is behind what we have already seen, wait for it to catch up
+		return nil
+	}
+
+	// Unwind blocks that are no longer canonical
+	for head != nil {
+		canonical := tip
+		if head.Index != tip.Index {
+			canonical, err = t.chain.BlockAt(ctx, head.Index)
+			if err != nil {
+				return err
+			}
+		}
+		if canonical.Hash == head.Hash {
+			break
+		}
+
+		if err := t.log.RemoveHead(); err != nil {
+			return err
+		}
+		head = t.log.Head()
+	}
+
+	if head == nil {
+		return t.log.AddBlock(tip)
+	}
+
+	for index := head.Index + 1; index <= tip.Index; index++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		block := tip
+		if index != tip.Index {
+			block, err = t.chain.BlockAt(ctx, index)
+			if err != nil {
+				return err
+			}
+		}
+		if err := t.log.AddBlock(block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EventsBlocks implements the /events/blocks endpoint
+func (t *Tracker) EventsBlocks(
+	ctx context.Context,
+	req *types.EventsBlocksRequest,
+) (*types.EventsBlocksResponse, *types.Error) {
+	offset := int64(0)
+	if req.Offset != nil {
+		offset = *req.Offset
+	}
+	limit := int64(maxEventsLimit)
+	if req.Limit != nil && *req.Limit < limit {
+		limit = *req.Limit
+	}
+	if offset < 0 || limit < 0 {
+		return nil, service.WrapError(service.ErrInvalidInput, errInvalidEventsRange)
+	}
+
+	events, err := t.log.Events(offset, limit)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+
+	// Read after the events so that max_sequence is never behind them
+	maxSequence := t.log.MaxSequence()
+	if maxSequence < 0 {
+		maxSequence = 0
+	}
+
+	return &types.EventsBlocksResponse{
+		MaxSequence: maxSequence,
+		Events:      events,
+	}, nil
+}
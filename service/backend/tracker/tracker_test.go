@@ -0,0 +1,153 @@
+package tracker
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	ethtypes "github.com/ava-labs/coreth/core/types"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	mocks "github.com/ava-labs/avalanche-rosetta/mocks/client"
+)
+
+var cChainNetworkIdentifier = &types.NetworkIdentifier{
+	Network: mapper.FujiNetwork,
+}
+
+func makeHeader(number int64, extra byte) *ethtypes.Header {
+	return &ethtypes.Header{
+		Number: big.NewInt(number),
+		Extra:  []byte{extra},
+	}
+}
+
+func blockIdentifier(header *ethtypes.Header) *types.BlockIdentifier {
+	return &types.BlockIdentifier{
+		Index: header.Number.Int64(),
+		Hash:  header.Hash().String(),
+	}
+}
+
+func TestTrackerSync(t *testing.T) {
+	ctx := context.Background()
+
+	header10 := makeHeader(10, 0)
+	header11 := makeHeader(11, 0)
+	header12 := makeHeader(12, 0)
+	header11b := makeHeader(11, 1)
+	header12b := makeHeader(12, 1)
+	header13b := makeHeader(13, 1)
+
+	clientMock := &mocks.Client{}
+	db := memdb.New()
+	blockLog, err := NewBlockLog(db)
+	assert.Nil(t, err)
+	tracker := NewTracker(NewCChain(clientMock), blockLog, cChainNetworkIdentifier)
+
+	t.Run("first sync starts at the tip", func(t *testing.T) {
+		clientMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(header10, nil).Once()
+
+		assert.Nil(t, tracker.Sync(ctx))
+		assert.Equal(t, blockIdentifier(header10), blockLog.Head())
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("new blocks are added in order", func(t *testing.T) {
+		clientMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(header12, nil).Once()
+		clientMock.On("HeaderByNumber", ctx, big.NewInt(10)).Return(header10, nil).Once()
+		clientMock.On("HeaderByNumber", ctx, big.NewInt(11)).Return(header11, nil).Once()
+
+		assert.Nil(t, tracker.Sync(ctx))
+		assert.Equal(t, blockIdentifier(header12), blockLog.Head())
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("reorged blocks are removed before new ones are added", func(t *testing.T) {
+		clientMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(header13b, nil).Once()
+		clientMock.On("HeaderByNumber", ctx, big.NewInt(12)).Return(header12b, nil).Twice()
+		clientMock.On("HeaderByNumber", ctx, big.NewInt(11)).Return(header11b, nil).Twice()
+		clientMock.On("HeaderByNumber", ctx, big.NewInt(10)).Return(header10, nil).Once()
+
+		assert.Nil(t, tracker.Sync(ctx))
+		assert.Equal(t, blockIdentifier(header13b), blockLog.Head())
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("events are returned in sequence", func(t *testing.T) {
+		resp, terr := tracker.EventsBlocks(ctx, &types.EventsBlocksRequest{
+			NetworkIdentifier: cChainNetworkIdentifier,
+		})
+		assert.Nil(t, terr)
+		assert.Equal(t, int64(7), resp.MaxSequence)
+
+		expected := []struct {
+			block     *ethtypes.Header
+			eventType types.BlockEventType
+		}{
+			{header10, types.ADDED},
+			{header11, types.ADDED},
+			{header12, types.ADDED},
+			{header12, types.REMOVED},
+			{header11, types.REMOVED},
+			{header11b, types.ADDED},
+			{header12b, types.ADDED},
+			{header13b, types.ADDED},
+		}
+		assert.Len(t, resp.Events, len(expected))
+		for i, e := range expected {
+			assert.Equal(t, int64(i), resp.Events[i].Sequence)
+			assert.Equal(t, blockIdentifier(e.block), resp.Events[i].BlockIdentifier)
+			assert.Equal(t, e.eventType, resp.Events[i].Type)
+		}
+
+		offset := int64(3)
+		limit := int64(2)
+		resp, terr = tracker.EventsBlocks(ctx, &types.EventsBlocksRequest{
+			NetworkIdentifier: cChainNetworkIdentifier,
+			Offset:            &offset,
+			Limit:             &limit,
+		})
+		assert.Nil(t, terr)
+		assert.Len(t, resp.Events, 2)
+		assert.Equal(t, int64(3), resp.Events[0].Sequence)
+		assert.Equal(t, int64(4), resp.Events[1].Sequence)
+	})
+
+	t.Run("node behind the log is ignored", func(t *testing.T) {
+		clientMock.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(header12b, nil).Once()
+
+		assert.Nil(t, tracker.Sync(ctx))
+		assert.Equal(t, blockIdentifier(header13b), blockLog.Head())
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("log is restored from the database", func(t *testing.T) {
+		restored, err := NewBlockLog(db)
+		assert.Nil(t, err)
+		assert.Equal(t, blockIdentifier(header13b), restored.Head())
+		assert.Equal(t, int64(7), restored.MaxSequence())
+	})
+}
+
+func TestShouldHandleRequest(t *testing.T) {
+	tracker := NewTracker(NewCChain(&mocks.Client{}), nil, cChainNetworkIdentifier)
+
+	assert.True(t, tracker.ShouldHandleRequest(&types.EventsBlocksRequest{
+		NetworkIdentifier: cChainNetworkIdentifier,
+	}))
+	assert.False(t, tracker.ShouldHandleRequest(&types.EventsBlocksRequest{
+		NetworkIdentifier: &types.NetworkIdentifier{
+			Network: mapper.FujiNetwork,
+			SubNetworkIdentifier: &types.SubNetworkIdentifier{
+				Network: mapper.PChainNetworkIdentifier,
+			},
+		},
+	}))
+	assert.False(t, tracker.ShouldHandleRequest(&types.NetworkRequest{
+		NetworkIdentifier: cChainNetworkIdentifier,
+	}))
+}
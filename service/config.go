@@ -20,6 +20,17 @@ type Config struct {
 
 	// Upgrade Times
 	AP5Activation uint64
+
+	// ReplacementFeeBumpPercent is the minimum gas price increase, in percent,
+	// of a transaction replacing a pending one
+	ReplacementFeeBumpPercent uint64
+
+	// GasLimitBufferPercent is added, in percent, to estimated gas limits
+	GasLimitBufferPercent uint64
+
+	// MaxFees caps the fee, in wei, of the transactions transferring a
+	// currency. It is keyed by AVAX or by the token contract address.
+	MaxFees map[string]*big.Int
 }
 
 const (
@@ -27,6 +38,10 @@ const (
 	ModeOnline         = "online"
 	StandardIngestion  = "standard"
 	AnalyticsIngestion = "analytics"
+
+	// DefaultReplacementFeeBumpPercent matches the minimum price bump the
+	// tx pool requires to replace a transaction
+	DefaultReplacementFeeBumpPercent = 10
 )
 
 // IsOfflineMode returns true if running in offline mode
@@ -54,6 +69,27 @@ func (c Config) IsTokenListEmpty() bool {
 	return len(c.TokenWhiteList) == 0
 }
 
+// ReplacementFeeBump returns the minimum gas price increase, in percent, of a
+// replacement transaction
+func (c Config) ReplacementFeeBump() uint64 {
+	if c.ReplacementFeeBumpPercent == 0 {
+		return DefaultReplacementFeeBumpPercent
+	}
+	return c.ReplacementFeeBumpPercent
+}
+
+// BufferGasLimit returns the estimated gasLimit increased by the gas limit
+// buffer
+func (c Config) BufferGasLimit(gasLimit uint64) uint64 {
+	return gasLimit + gasLimit*c.GasLimitBufferPercent/100
+}
+
+// MaxFee returns the fee cap of the transactions transferring the currency
+// keyed by key, or nil if they are not capped
+func (c Config) MaxFee(key string) *big.Int {
+	return c.MaxFees[key]
+}
+
 // Signer returns an eth signer object for a given chain
 func (c Config) Signer() ethtypes.Signer {
 	return ethtypes.LatestSignerForChainID(c.ChainID)
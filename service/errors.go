@@ -19,6 +19,7 @@ var (
 		ErrCallInvalidMethod,
 		ErrCallInvalidParams,
 		ErrTransactionNotFound,
+		ErrInvalidSignature,
 	}
 
 	// General errors
@@ -35,6 +36,7 @@ var (
 	ErrCallInvalidMethod   = makeError(10, "Invalid call method", false)
 	ErrCallInvalidParams   = makeError(11, "invalid call params", false)
 	ErrTransactionNotFound = makeError(12, "Transaction was not found", true)
+	ErrInvalidSignature    = makeError(13, "Signature does not match the expected signer", false)
 )
 
 func makeError(code int32, message string, retriable bool) *types.Error {
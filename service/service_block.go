@@ -11,8 +11,10 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/coinbase/rosetta-sdk-go/utils"
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/logger"
 	"github.com/ava-labs/avalanche-rosetta/mapper"
 )
 
@@ -22,6 +24,11 @@ type BlockBackend interface {
 	BlockTransaction(ctx context.Context, request *types.BlockTransactionRequest) (*types.BlockTransactionResponse, *types.Error)
 }
 
+// BlockIndexer records the blocks served by the BlockService
+type BlockIndexer interface {
+	IndexBlock(networkIdentifier *types.NetworkIdentifier, block *types.Block) error
+}
+
 // BlockService implements the /block/* endpoints
 type BlockService struct {
 	config *Config
@@ -30,15 +37,22 @@ type BlockService struct {
 	genesisBlock  *types.Block
 	pChainBackend BlockBackend
 	pChainBlockID *ids.ID
+	blockIndexer  BlockIndexer
 }
 
 // NewBlockService returns a new block servicer
-func NewBlockService(config *Config, c client.Client, pChainBackend BlockBackend) server.BlockAPIServicer {
+func NewBlockService(
+	config *Config,
+	c client.Client,
+	pChainBackend BlockBackend,
+	blockIndexer BlockIndexer,
+) server.BlockAPIServicer {
 	return &BlockService{
 		config:        config,
 		client:        c,
 		genesisBlock:  makeGenesisBlock(config.GenesisBlockHash),
 		pChainBackend: pChainBackend,
+		blockIndexer:  blockIndexer,
 	}
 }
 
@@ -46,6 +60,29 @@ func NewBlockService(config *Config, c client.Client, pChainBackend BlockBackend
 func (s *BlockService) Block(
 	ctx context.Context,
 	request *types.BlockRequest,
+) (*types.BlockResponse, *types.Error) {
+	resp, terr := s.block(ctx, request)
+	if terr != nil {
+		return nil, terr
+	}
+
+	// Indexing is best effort, a failure must not prevent serving the block
+	if s.blockIndexer != nil && resp.Block != nil {
+		if err := s.blockIndexer.IndexBlock(request.NetworkIdentifier, resp.Block); err != nil {
+			logger.FromContext(ctx).Warn(
+				"failed to index block",
+				zap.Int64("index", resp.Block.BlockIdentifier.Index),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *BlockService) block(
+	ctx context.Context,
+	request *types.BlockRequest,
 ) (*types.BlockResponse, *types.Error) {
 	if s.config.IsOfflineMode() {
 		return nil, ErrUnavailableOffline
@@ -3,17 +3,87 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
 
 	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	ethtypes "github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/interfaces"
 	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
+const (
+	// maxGetLogsBlockRange is the maximum number of blocks eth_getLogs can query at once
+	maxGetLogsBlockRange = 2048
+
+	hashLength = 66
+)
+
+var (
+	errInvalidAddress    = errors.New("invalid address")
+	errInvalidHash       = errors.New("invalid hash")
+	errInvalidBlockRange = errors.New("invalid block range")
+)
+
+type callHandler func(s CallService, ctx context.Context, req *types.CallRequest) (*types.CallResponse, *types.Error)
+
+// callRegistry maps the C-chain call methods to their handlers
+// listed by CallMethods.
+var callRegistry = map[string]callHandler{
+	"eth_getTransactionReceipt": CallService.callGetTransactionReceipt,
+	"eth_call":                  CallService.callCall,
+	"eth_getLogs":               CallService.callGetLogs,
+	"eth_getCode":               CallService.callGetCode,
+	"eth_getStorageAt":          CallService.callGetStorageAt,
+	"eth_estimateGas":           CallService.callEstimateGas,
+	"debug_traceTransaction":    CallService.callTraceTransaction,
+
+	mapper.CallSimulateTransaction: CallService.callSimulateTransaction,
+}
+
+// CallMethods returns the sorted call methods of the call registry merged
+// with the call methods registered by the given backends, without duplicates
+func CallMethods(backendMethods ...[]string) []string {
+	seen := map[string]bool{}
+	methods := []string{}
+	add := func(method string) {
+		if !seen[method] {
+			seen[method] = true
+			methods = append(methods, method)
+		}
+	}
+	for method := range callRegistry {
+		add(method)
+	}
+	for _, backend := range backendMethods {
+		for _, method := range backend {
+			add(method)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// CallBackend represents a backend that implements /call family of apis for a subset of requests
+type CallBackend interface {
+	// ShouldHandleRequest returns whether a given request should be handled by this backend
+	ShouldHandleRequest(req interface{}) bool
+	// Call implements /call endpoint
+	Call(ctx context.Context, req *types.CallRequest) (*types.CallResponse, *types.Error)
+}
+
 // CallService implements /call/* endpoints
 type CallService struct {
-	config *Config
-	client client.Client
+	config                *Config
+	client                client.Client
+	pChainBackend         CallBackend
+	cChainAtomicTxBackend CallBackend
+	submissionBackend     CallBackend
 }
 
 // GetTransactionReceiptInput is the input to the call
@@ -22,11 +92,67 @@ type GetTransactionReceiptInput struct {
 	TxHash string `json:"tx_hash"`
 }
 
-// NewCallService returns a new call servicer
-func NewCallService(config *Config, client client.Client) server.CallAPIServicer {
+// CallMsgInput is the input to the call methods "eth_call" and "eth_estimateGas".
+// Quantities are hex encoded. BlockIdentifier is ignored by "eth_estimateGas".
+type CallMsgInput struct {
+	From            string                        `json:"from"`
+	To              string                        `json:"to"`
+	Data            string                        `json:"data"`
+	Value           string                        `json:"value"`
+	Gas             string                        `json:"gas"`
+	GasPrice        string                        `json:"gas_price"`
+	BlockIdentifier *types.PartialBlockIdentifier `json:"block_identifier"`
+}
+
+// GetLogsInput is the input to the call method "eth_getLogs".
+// Either BlockHash or a range of at most maxGetLogsBlockRange blocks must be provided.
+type GetLogsInput struct {
+	Addresses []string   `json:"addresses"`
+	Topics    [][]string `json:"topics"`
+	FromBlock *int64     `json:"from_block"`
+	ToBlock   *int64     `json:"to_block"`
+	BlockHash string     `json:"block_hash"`
+}
+
+// GetCodeInput is the input to the call method "eth_getCode".
+type GetCodeInput struct {
+	Address         string                        `json:"address"`
+	BlockIdentifier *types.PartialBlockIdentifier `json:"block_identifier"`
+}
+
+// GetStorageAtInput is the input to the call method "eth_getStorageAt".
+type GetStorageAtInput struct {
+	Address         string                        `json:"address"`
+	Position        string                        `json:"position"`
+	BlockIdentifier *types.PartialBlockIdentifier `json:"block_identifier"`
+}
+
+// TraceTransactionInput is the input to the call method "debug_traceTransaction".
+type TraceTransactionInput struct {
+	TxHash string `json:"tx_hash"`
+}
+
+// SimulateTransactionInput is the input to the call method mapper.CallSimulateTransaction.
+// UnsignedTransaction is the unsigned transaction returned by /construction/payloads.
+type SimulateTransactionInput struct {
+	UnsignedTransaction string `json:"unsigned_transaction"`
+}
+
+// NewCallService returns a new call servicer.
+// submissionBackend answers the status calls of submitted transactions on both chains.
+func NewCallService(
+	config *Config,
+	client client.Client,
+	pChainBackend CallBackend,
+	cChainAtomicTxBackend CallBackend,
+	submissionBackend CallBackend,
+) server.CallAPIServicer {
 	return &CallService{
-		config: config,
-		client: client,
+		config:                config,
+		client:                client,
+		pChainBackend:         pChainBackend,
+		cChainAtomicTxBackend: cChainAtomicTxBackend,
+		submissionBackend:     submissionBackend,
 	}
 }
 
@@ -39,12 +165,24 @@ func (s CallService) Call(
 		return nil, ErrUnavailableOffline
 	}
 
-	switch req.Method {
-	case "eth_getTransactionReceipt":
-		return s.callGetTransactionReceipt(ctx, req)
-	default:
+	if s.submissionBackend != nil && s.submissionBackend.ShouldHandleRequest(req) {
+		return s.submissionBackend.Call(ctx, req)
+	}
+
+	if s.pChainBackend.ShouldHandleRequest(req) {
+		return s.pChainBackend.Call(ctx, req)
+	}
+
+	if s.cChainAtomicTxBackend != nil && s.cChainAtomicTxBackend.ShouldHandleRequest(req) {
+		return s.cChainAtomicTxBackend.Call(ctx, req)
+	}
+
+	handler, ok := callRegistry[req.Method]
+	if !ok {
 		return nil, ErrCallInvalidMethod
 	}
+
+	return handler(s, ctx, req)
 }
 
 func (s CallService) callGetTransactionReceipt(
@@ -77,3 +215,392 @@ func (s CallService) callGetTransactionReceipt(
 
 	return &types.CallResponse{Result: receiptMap}, nil
 }
+
+func (s CallService) callCall(
+	ctx context.Context,
+	req *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	var input CallMsgInput
+	if err := types.UnmarshalMap(req.Parameters, &input); err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+
+	if len(input.To) == 0 {
+		return nil, WrapError(ErrCallInvalidParams, "to missing from params")
+	}
+
+	msg, err := input.callMsg()
+	if err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+
+	header, terr := BlockHeaderFromInput(ctx, s.client, input.BlockIdentifier)
+	if terr != nil {
+		return nil, terr
+	}
+
+	data, err := s.client.CallContract(ctx, msg, header.Number)
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"data":             hexutil.Encode(data),
+			"block_identifier": blockIdentifierFromHeader(header),
+		},
+	}, nil
+}
+
+func (s CallService) callGetLogs(
+	ctx context.Context,
+	req *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	var input GetLogsInput
+	if err := types.UnmarshalMap(req.Parameters, &input); err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+
+	query, err := input.filterQuery()
+	if err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+
+	logs, err := s.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"logs": logs,
+		},
+	}, nil
+}
+
+func (s CallService) callGetCode(
+	ctx context.Context,
+	req *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	var input GetCodeInput
+	if err := types.UnmarshalMap(req.Parameters, &input); err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+
+	if !common.IsHexAddress(input.Address) {
+		return nil, WrapError(ErrCallInvalidParams, errInvalidAddress)
+	}
+
+	header, terr := BlockHeaderFromInput(ctx, s.client, input.BlockIdentifier)
+	if terr != nil {
+		return nil, terr
+	}
+
+	code, err := s.client.CodeAt(ctx, common.HexToAddress(input.Address), header.Number)
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"code":             hexutil.Encode(code),
+			"block_identifier": blockIdentifierFromHeader(header),
+		},
+	}, nil
+}
+
+func (s CallService) callGetStorageAt(
+	ctx context.Context,
+	req *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	var input GetStorageAtInput
+	if err := types.UnmarshalMap(req.Parameters, &input); err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+
+	if !common.IsHexAddress(input.Address) {
+		return nil, WrapError(ErrCallInvalidParams, errInvalidAddress)
+	}
+
+	position, err := hexutil.DecodeBig(input.Position)
+	if err != nil {
+		return nil, WrapError(ErrCallInvalidParams, "position must be a hex encoded quantity")
+	}
+
+	header, terr := BlockHeaderFromInput(ctx, s.client, input.BlockIdentifier)
+	if terr != nil {
+		return nil, terr
+	}
+
+	value, err := s.client.StorageAt(
+		ctx,
+		common.HexToAddress(input.Address),
+		common.BigToHash(position),
+		header.Number,
+	)
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"value":            hexutil.Encode(value),
+			"block_identifier": blockIdentifierFromHeader(header),
+		},
+	}, nil
+}
+
+func (s CallService) callEstimateGas(
+	ctx context.Context,
+	req *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	var input CallMsgInput
+	if err := types.UnmarshalMap(req.Parameters, &input); err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+
+	msg, err := input.callMsg()
+	if err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+
+	gas, err := s.client.EstimateGas(ctx, msg)
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"gas": gas,
+		},
+	}, nil
+}
+
+func (s CallService) callTraceTransaction(
+	ctx context.Context,
+	req *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	var input TraceTransactionInput
+	if err := types.UnmarshalMap(req.Parameters, &input); err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+
+	if len(input.TxHash) != hashLength || !has0xPrefix(input.TxHash) {
+		return nil, WrapError(ErrCallInvalidParams, errInvalidHash)
+	}
+
+	trace, _, err := s.client.TraceTransaction(ctx, input.TxHash)
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"trace": trace,
+		},
+		Idempotent: true,
+	}, nil
+}
+
+// callSimulateTransaction traces an unsigned transaction, or every transaction
+// of an unsigned batch, on top of the latest block so a transaction that would
+// revert is caught before it is signed
+func (s CallService) callSimulateTransaction(
+	ctx context.Context,
+	req *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	var input SimulateTransactionInput
+	if err := types.UnmarshalMap(req.Parameters, &input); err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+
+	if len(input.UnsignedTransaction) == 0 {
+		return nil, WrapError(ErrCallInvalidParams, "unsigned_transaction missing from params")
+	}
+
+	txs, err := decodeUnsignedTransactions(input.UnsignedTransaction)
+	if err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+
+	results := make([]map[string]interface{}, len(txs))
+	reverted := false
+	for i, tx := range txs {
+		result, terr := s.simulateTransaction(ctx, tx)
+		if terr != nil {
+			return nil, terr
+		}
+		results[i] = result
+		reverted = reverted || result["reverted"].(bool)
+	}
+
+	if len(results) == 1 {
+		return &types.CallResponse{Result: results[0]}, nil
+	}
+	return &types.CallResponse{
+		Result: map[string]interface{}{
+			"reverted":     reverted,
+			"transactions": results,
+		},
+	}, nil
+}
+
+// simulateTransaction traces tx and returns whether it reverts, the operations
+// it produces and its estimated gas
+func (s CallService) simulateTransaction(ctx context.Context, tx *transaction) (map[string]interface{}, *types.Error) {
+	// Contract deployments have no recipient
+	isDeployment := len(tx.To) == 0
+	if !common.IsHexAddress(tx.From) || (!isDeployment && !common.IsHexAddress(tx.To)) {
+		return nil, WrapError(ErrCallInvalidParams, errInvalidAddress)
+	}
+
+	msg := interfaces.CallMsg{
+		From:       common.HexToAddress(tx.From),
+		Value:      tx.Value,
+		Data:       tx.Data,
+		Gas:        tx.GasLimit,
+		GasPrice:   tx.GasPrice,
+		AccessList: tx.AccessList,
+	}
+	if !isDeployment {
+		to := common.HexToAddress(tx.To)
+		msg.To = &to
+	}
+	if tx.MaxFeePerGas != nil {
+		msg.GasPrice = nil
+		msg.GasFeeCap = tx.MaxFeePerGas
+		msg.GasTipCap = tx.MaxPriorityFeePerGas
+	}
+
+	trace, flattenedTrace, err := s.client.TraceCall(ctx, msg)
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+
+	result := map[string]interface{}{
+		"reverted": trace.Revert,
+	}
+	if trace.Revert {
+		result["error"] = trace.Error
+	} else {
+		gas, err := s.client.EstimateGas(ctx, msg)
+		if err != nil {
+			return nil, WrapError(ErrClientError, err)
+		}
+		result["estimated_gas"] = gas
+	}
+
+	operations, err := mapper.SimulatedOperations(flattenedTrace, trace.EmittedLogs(), s.client)
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+	result["operations"] = operations
+
+	return result, nil
+}
+
+func (i *CallMsgInput) callMsg() (interfaces.CallMsg, error) {
+	var msg interfaces.CallMsg
+
+	if len(i.From) > 0 {
+		if !common.IsHexAddress(i.From) {
+			return msg, errInvalidAddress
+		}
+		msg.From = common.HexToAddress(i.From)
+	}
+
+	if len(i.To) > 0 {
+		if !common.IsHexAddress(i.To) {
+			return msg, errInvalidAddress
+		}
+		to := common.HexToAddress(i.To)
+		msg.To = &to
+	}
+
+	if len(i.Data) > 0 {
+		data, err := hexutil.Decode(i.Data)
+		if err != nil {
+			return msg, err
+		}
+		msg.Data = data
+	}
+
+	if len(i.Value) > 0 {
+		value, err := hexutil.DecodeBig(i.Value)
+		if err != nil {
+			return msg, err
+		}
+		msg.Value = value
+	}
+
+	if len(i.Gas) > 0 {
+		gas, err := hexutil.DecodeUint64(i.Gas)
+		if err != nil {
+			return msg, err
+		}
+		msg.Gas = gas
+	}
+
+	if len(i.GasPrice) > 0 {
+		gasPrice, err := hexutil.DecodeBig(i.GasPrice)
+		if err != nil {
+			return msg, err
+		}
+		msg.GasPrice = gasPrice
+	}
+
+	return msg, nil
+}
+
+func (i *GetLogsInput) filterQuery() (interfaces.FilterQuery, error) {
+	var query interfaces.FilterQuery
+
+	for _, addr := range i.Addresses {
+		if !common.IsHexAddress(addr) {
+			return query, errInvalidAddress
+		}
+		query.Addresses = append(query.Addresses, common.HexToAddress(addr))
+	}
+
+	for _, topicSet := range i.Topics {
+		hashes := make([]common.Hash, 0, len(topicSet))
+		for _, topic := range topicSet {
+			if len(topic) != hashLength || !has0xPrefix(topic) {
+				return query, errInvalidHash
+			}
+			hashes = append(hashes, common.HexToHash(topic))
+		}
+		query.Topics = append(query.Topics, hashes)
+	}
+
+	if len(i.BlockHash) > 0 {
+		if i.FromBlock != nil || i.ToBlock != nil {
+			return query, errors.New("block_hash cannot be combined with from_block or to_block")
+		}
+		if len(i.BlockHash) != hashLength || !has0xPrefix(i.BlockHash) {
+			return query, errInvalidHash
+		}
+		blockHash := common.HexToHash(i.BlockHash)
+		query.BlockHash = &blockHash
+		return query, nil
+	}
+
+	if i.FromBlock == nil || i.ToBlock == nil {
+		return query, errors.New("from_block and to_block or block_hash must be provided")
+	}
+	if *i.FromBlock < 0 || *i.FromBlock > *i.ToBlock || *i.ToBlock-*i.FromBlock >= maxGetLogsBlockRange {
+		return query, errInvalidBlockRange
+	}
+
+	query.FromBlock = big.NewInt(*i.FromBlock)
+	query.ToBlock = big.NewInt(*i.ToBlock)
+
+	return query, nil
+}
+
+func blockIdentifierFromHeader(header *ethtypes.Header) *types.BlockIdentifier {
+	return &types.BlockIdentifier{
+		Index: header.Number.Int64(),
+		Hash:  header.Hash().String(),
+	}
+}
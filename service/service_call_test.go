@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"testing"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	mocks "github.com/ava-labs/avalanche-rosetta/mocks/client"
+	backendMocks "github.com/ava-labs/avalanche-rosetta/mocks/service"
+)
+
+func TestCallMethods(t *testing.T) {
+	methods := CallMethods()
+	assert.Len(t, methods, len(callRegistry))
+	assert.True(t, sort.StringsAreSorted(methods))
+	for method := range callRegistry {
+		assert.Contains(t, methods, method)
+	}
+
+	methods = CallMethods(
+		[]string{mapper.CallSelectUTXOs},
+		[]string{mapper.CallGetSubmittedTransactionStatus, mapper.CallSelectUTXOs},
+	)
+	assert.Len(t, methods, len(callRegistry)+2)
+	assert.True(t, sort.StringsAreSorted(methods))
+	assert.Contains(t, methods, mapper.CallGetSubmittedTransactionStatus)
+	assert.Contains(t, methods, mapper.CallSelectUTXOs)
+}
+
+func TestCall(t *testing.T) {
+	ctx := context.Background()
+	clientMock := &mocks.Client{}
+	pBackendMock := &backendMocks.CallBackend{}
+	service := CallService{
+		config:        &Config{Mode: ModeOnline},
+		client:        clientMock,
+		pChainBackend: pBackendMock,
+	}
+
+	t.Run("p-chain request is delegated to p-chain backend", func(t *testing.T) {
+		req := &types.CallRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{
+				Network: mapper.FujiNetwork,
+				SubNetworkIdentifier: &types.SubNetworkIdentifier{
+					Network: mapper.PChainNetworkIdentifier,
+				},
+			},
+			Method: "platform.getMinStake",
+		}
+
+		expectedResp := &types.CallResponse{}
+		pBackendMock.On("ShouldHandleRequest", req).Return(true).Once()
+		pBackendMock.On("Call", mock.Anything, req).Return(expectedResp, nil).Once()
+
+		resp, err := service.Call(ctx, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResp, resp)
+		pBackendMock.AssertExpectations(t)
+	})
+
+	t.Run("submission status request is delegated to submission backend", func(t *testing.T) {
+		submissionBackendMock := &backendMocks.CallBackend{}
+		trackingService := service
+		trackingService.submissionBackend = submissionBackendMock
+
+		req := &types.CallRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{Network: mapper.FujiNetwork},
+			Method:            mapper.CallGetSubmittedTransactionStatus,
+		}
+
+		expectedResp := &types.CallResponse{}
+		submissionBackendMock.On("ShouldHandleRequest", req).Return(true).Once()
+		submissionBackendMock.On("Call", mock.Anything, req).Return(expectedResp, nil).Once()
+
+		resp, err := trackingService.Call(ctx, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResp, resp)
+		submissionBackendMock.AssertExpectations(t)
+		pBackendMock.AssertExpectations(t)
+	})
+
+	t.Run("utxo selection on c-chain is delegated to atomic tx backend", func(t *testing.T) {
+		atomicBackendMock := &backendMocks.CallBackend{}
+		atomicService := service
+		atomicService.cChainAtomicTxBackend = atomicBackendMock
+
+		req := &types.CallRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{Network: mapper.FujiNetwork},
+			Method:            mapper.CallSelectUTXOs,
+		}
+
+		expectedResp := &types.CallResponse{}
+		pBackendMock.On("ShouldHandleRequest", req).Return(false).Once()
+		atomicBackendMock.On("ShouldHandleRequest", req).Return(true).Once()
+		atomicBackendMock.On("Call", mock.Anything, req).Return(expectedResp, nil).Once()
+
+		resp, err := atomicService.Call(ctx, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResp, resp)
+		atomicBackendMock.AssertExpectations(t)
+		pBackendMock.AssertExpectations(t)
+	})
+
+	t.Run("unknown method is rejected", func(t *testing.T) {
+		req := &types.CallRequest{Method: "eth_sendRawTransaction"}
+		pBackendMock.On("ShouldHandleRequest", req).Return(false).Once()
+
+		resp, err := service.Call(ctx, req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, ErrCallInvalidMethod, err)
+	})
+
+	t.Run("eth_getCode validates address", func(t *testing.T) {
+		req := &types.CallRequest{
+			Method:     "eth_getCode",
+			Parameters: map[string]interface{}{"address": "0x1234"},
+		}
+		pBackendMock.On("ShouldHandleRequest", req).Return(false).Once()
+
+		resp, err := service.Call(ctx, req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, ErrCallInvalidParams.Code, err.Code)
+	})
+
+	t.Run("eth_getLogs rejects unbounded ranges", func(t *testing.T) {
+		req := &types.CallRequest{
+			Method: "eth_getLogs",
+			Parameters: map[string]interface{}{
+				"from_block": 0,
+				"to_block":   maxGetLogsBlockRange,
+			},
+		}
+		pBackendMock.On("ShouldHandleRequest", req).Return(false).Once()
+
+		resp, err := service.Call(ctx, req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, ErrCallInvalidParams.Code, err.Code)
+		assert.Equal(t, errInvalidBlockRange.Error(), err.Details["error"])
+	})
+
+	t.Run("eth_estimateGas", func(t *testing.T) {
+		to := common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+		req := &types.CallRequest{
+			Method: "eth_estimateGas",
+			Parameters: map[string]interface{}{
+				"from":  "0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309",
+				"to":    to.Hex(),
+				"value": "0x2a",
+			},
+		}
+		pBackendMock.On("ShouldHandleRequest", req).Return(false).Once()
+		clientMock.On("EstimateGas", ctx, mock.Anything).Return(uint64(21000), nil).Once()
+
+		resp, err := service.Call(ctx, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(21000), resp.Result["gas"])
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("eth_call at block", func(t *testing.T) {
+		to := common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+		index := int64(10)
+		req := &types.CallRequest{
+			Method: "eth_call",
+			Parameters: map[string]interface{}{
+				"to":               to.Hex(),
+				"data":             "0x70a08231",
+				"block_identifier": map[string]interface{}{"index": index},
+			},
+		}
+		header := &ethtypes.Header{Number: big.NewInt(index)}
+		pBackendMock.On("ShouldHandleRequest", req).Return(false).Once()
+		clientMock.On("HeaderByNumber", ctx, big.NewInt(index)).Return(header, nil).Once()
+		clientMock.On("CallContract", ctx, mock.Anything, big.NewInt(index)).Return([]byte{0x01}, nil).Once()
+
+		resp, err := service.Call(ctx, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "0x01", resp.Result["data"])
+		assert.Equal(t, blockIdentifierFromHeader(header), resp.Result["block_identifier"])
+		clientMock.AssertExpectations(t)
+	})
+	t.Run("simulate erc20 transfer", func(t *testing.T) {
+		from := "0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309"
+		recipient := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+		contract := common.HexToAddress("0x9702230A8Ea53601f5cD2dc00fDBc13d4dF4A8c7")
+		currency := mapper.ToCurrency("USDT", 6, contract)
+		unsignedTx, _ := json.Marshal(&transaction{
+			From:     from,
+			To:       contract.Hex(),
+			Value:    big.NewInt(0),
+			Data:     generateErc20TransferData(recipient, big.NewInt(1_000)),
+			GasPrice: big.NewInt(25_000_000_000),
+			GasLimit: 250_000,
+			ChainID:  big.NewInt(43113),
+			Currency: currency,
+		})
+		req := &types.CallRequest{
+			Method:     mapper.CallSimulateTransaction,
+			Parameters: map[string]interface{}{"unsigned_transaction": string(unsignedTx)},
+		}
+		trace := &client.Call{
+			Type: mapper.OpCall,
+			Logs: []*client.CallLog{{
+				Address: contract,
+				Topics: []common.Hash{
+					common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+					common.HexToAddress(from).Hash(),
+					common.HexToAddress(recipient).Hash(),
+				},
+				Data: common.LeftPadBytes(big.NewInt(1_000).Bytes(), common.HashLength),
+			}},
+		}
+		flattenedTrace := []*client.FlatCall{{
+			Type:    mapper.OpCall,
+			From:    common.HexToAddress(from),
+			To:      contract,
+			Value:   big.NewInt(0),
+			GasUsed: big.NewInt(30_000),
+		}}
+		pBackendMock.On("ShouldHandleRequest", req).Return(false).Once()
+		clientMock.On("TraceCall", ctx, mock.Anything).Return(trace, flattenedTrace, nil).Once()
+		clientMock.On("EstimateGas", ctx, mock.Anything).Return(uint64(51_000), nil).Once()
+		clientMock.On("GetContractInfo", contract, true).Return("USDT", uint8(6), nil).Once()
+
+		resp, err := service.Call(ctx, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, false, resp.Result["reverted"])
+		assert.Equal(t, uint64(51_000), resp.Result["estimated_gas"])
+		ops := resp.Result["operations"].([]*types.Operation)
+		assert.Len(t, ops, 2)
+		assert.Equal(t, mapper.OpErc20Transfer, ops[0].Type)
+		assert.Equal(t, from, ops[0].Account.Address)
+		assert.Equal(t, "-1000", ops[0].Amount.Value)
+		assert.Equal(t, recipient, ops[1].Account.Address)
+		assert.Equal(t, "1000", ops[1].Amount.Value)
+		assert.Equal(t, currency, ops[1].Amount.Currency)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("simulate batch", func(t *testing.T) {
+		from := common.HexToAddress("0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309")
+		recipients := []common.Address{
+			common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"),
+			common.HexToAddress("0x9702230A8Ea53601f5cD2dc00fDBc13d4dF4A8c7"),
+		}
+		batch := &batchTransaction{}
+		for i, recipient := range recipients {
+			batch.Transactions = append(batch.Transactions, &transaction{
+				From:     from.Hex(),
+				To:       recipient.Hex(),
+				Value:    big.NewInt(42),
+				Data:     []byte{},
+				Nonce:    uint64(i),
+				GasPrice: big.NewInt(25_000_000_000),
+				GasLimit: 21_000,
+				ChainID:  big.NewInt(43113),
+			})
+		}
+		unsignedTx, _ := json.Marshal(batch)
+		req := &types.CallRequest{
+			Method:     mapper.CallSimulateTransaction,
+			Parameters: map[string]interface{}{"unsigned_transaction": string(unsignedTx)},
+		}
+		pBackendMock.On("ShouldHandleRequest", req).Return(false).Once()
+		for i, recipient := range recipients {
+			recipient := recipient
+			flattenedTrace := []*client.FlatCall{{
+				Type:    mapper.OpCall,
+				From:    from,
+				To:      recipient,
+				Value:   big.NewInt(42),
+				GasUsed: big.NewInt(21_000),
+				Revert:  i == 1,
+			}}
+			matchRecipient := mock.MatchedBy(func(msg interfaces.CallMsg) bool {
+				return *msg.To == recipient
+			})
+			clientMock.On("TraceCall", ctx, matchRecipient).
+				Return(&client.Call{Type: mapper.OpCall, Revert: i == 1}, flattenedTrace, nil).Once()
+		}
+		clientMock.On("EstimateGas", ctx, mock.Anything).Return(uint64(21_000), nil).Once()
+
+		resp, err := service.Call(ctx, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, true, resp.Result["reverted"])
+		results := resp.Result["transactions"].([]map[string]interface{})
+		assert.Len(t, results, 2)
+		assert.Equal(t, false, results[0]["reverted"])
+		assert.Equal(t, uint64(21_000), results[0]["estimated_gas"])
+		assert.Equal(t, true, results[1]["reverted"])
+		assert.Len(t, results[1]["operations"], 2)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("simulate reverted transfer", func(t *testing.T) {
+		from := common.HexToAddress("0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309")
+		to := common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+		unsignedTx, _ := json.Marshal(&transaction{
+			From:     from.Hex(),
+			To:       to.Hex(),
+			Value:    big.NewInt(42),
+			Data:     []byte{},
+			GasPrice: big.NewInt(25_000_000_000),
+			GasLimit: 21_000,
+			ChainID:  big.NewInt(43113),
+		})
+		req := &types.CallRequest{
+			Method:     mapper.CallSimulateTransaction,
+			Parameters: map[string]interface{}{"unsigned_transaction": string(unsignedTx)},
+		}
+		trace := &client.Call{Type: mapper.OpCall, Revert: true, Error: "execution reverted"}
+		flattenedTrace := []*client.FlatCall{{
+			Type:    mapper.OpCall,
+			From:    from,
+			To:      to,
+			Value:   big.NewInt(42),
+			GasUsed: big.NewInt(0),
+			Revert:  true,
+			Error:   "execution reverted",
+		}}
+		pBackendMock.On("ShouldHandleRequest", req).Return(false).Once()
+		clientMock.On("TraceCall", ctx, mock.Anything).Return(trace, flattenedTrace, nil).Once()
+
+		resp, err := service.Call(ctx, req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, true, resp.Result["reverted"])
+		assert.Equal(t, "execution reverted", resp.Result["error"])
+		assert.NotContains(t, resp.Result, "estimated_gas")
+		ops := resp.Result["operations"].([]*types.Operation)
+		assert.Len(t, ops, 2)
+		assert.Equal(t, mapper.StatusFailure, *ops[0].Status)
+		clientMock.AssertExpectations(t)
+	})
+}
@@ -1,10 +1,14 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
 
 	ethtypes "github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/interfaces"
@@ -26,6 +30,39 @@ const (
 
 	transferFnSignature = "transfer(address,uint256)" // do not include spaces in the string
 	transferDataLength  = 68                          // 4 (method id) + 2*32 (args)
+
+	safeTransferFromFnSignature = "safeTransferFrom(address,address,uint256)"
+	safeTransferFromDataLength  = 100 // 4 (method id) + 3*32 (args)
+
+	// maxBatchTransfers is the maximum number of transfers in a batch
+	maxBatchTransfers = 100
+)
+
+var (
+	errBatchTooLarge         = fmt.Errorf("a batch holds at most %d transfers", maxBatchTransfers)
+	errBatchMetadataMismatch = errors.New("metadata doesn't match the transfers of the batch")
+	errMissingSignature      = errors.New("no signature for a transaction of the batch")
+
+	errMissingBytecode           = errors.New("bytecode must be populated in operation metadata")
+	errInvalidConstructorArgs    = errors.New("constructorArgs must be a hex string")
+	errPositiveDeploymentAmount  = errors.New("contract deployment amount must be negative or zero")
+	errInvalidDeploymentCurrency = errors.New("contract deployment amount must be in AVAX")
+
+	errInvalidReplaceTxHash = errors.New("replace_tx_hash is not a valid transaction hash")
+	errReplacedTxNotPending = errors.New("transaction to replace is not pending")
+	errReplacedTxSender     = errors.New("transaction to replace is not sent by the from address")
+	errBatchReplacement     = errors.New("a batch cannot replace a transaction")
+	errDynamicFeeGasPrice   = errors.New("a gas price cannot be set along with EIP-1559 fees")
+	errMaxFeeBelowTip       = errors.New("max fee per gas is below the max priority fee per gas")
+	errMaxFeeExceeded       = errors.New("fee exceeds the max fee")
+	errNoGasPriceOracle     = errors.New("gas price options are not available")
+)
+
+// Gas price options suggested by the gas price oracle
+const (
+	gasPriceSlow   = "slow"
+	gasPriceNormal = "normal"
+	gasPriceFast   = "fast"
 )
 
 type ConstructionBackend interface {
@@ -40,12 +77,34 @@ type ConstructionBackend interface {
 	ConstructionSubmit(ctx context.Context, req *types.ConstructionSubmitRequest) (*types.TransactionIdentifierResponse, *types.Error)
 }
 
+// SubmissionTracker records the transactions submitted through /construction/submit
+type SubmissionTracker interface {
+	TrackEvmTx(tx *ethtypes.Transaction)
+	TrackAtomicTx(hash string)
+	TrackPChainTx(hash string)
+}
+
+// NonceReserver hands out distinct nonces to concurrent constructions from the
+// same account
+type NonceReserver interface {
+	ReserveNonce(ctx context.Context, address ethcommon.Address) (uint64, error)
+}
+
+// GasPriceOracle suggests a slow, a normal and a fast gas price from the
+// latest blocks
+type GasPriceOracle interface {
+	SuggestGasPrices(ctx context.Context) (*big.Int, *big.Int, *big.Int, error)
+}
+
 // ConstructionService implements /construction/* endpoints
 type ConstructionService struct {
 	config                *Config
 	client                client.Client
 	pChainBackend         ConstructionBackend
 	cChainAtomicTxBackend ConstructionBackend
+	submissionTracker     SubmissionTracker
+	nonceReserver         NonceReserver
+	gasPriceOracle        GasPriceOracle
 }
 
 // NewConstructionService returns a new construction service
@@ -54,12 +113,18 @@ func NewConstructionService(
 	client client.Client,
 	pChainBackend ConstructionBackend,
 	cChainAtomicTxBackend ConstructionBackend,
+	submissionTracker SubmissionTracker,
+	nonceReserver NonceReserver,
+	gasPriceOracle GasPriceOracle,
 ) server.ConstructionAPIServicer {
 	return &ConstructionService{
 		config:                config,
 		client:                client,
 		pChainBackend:         pChainBackend,
 		cChainAtomicTxBackend: cChainAtomicTxBackend,
+		submissionTracker:     submissionTracker,
+		nonceReserver:         nonceReserver,
+		gasPriceOracle:        gasPriceOracle,
 	}
 }
 
@@ -70,18 +135,21 @@ func NewConstructionService(
 // or even arbitrary chain state. The request used when calling this endpoint
 // is created by calling /construction/preprocess in an offline environment.
 //
+// P-chain metadata is also served offline, as the network constants it needs are
+// known ahead of time. The fee must then be supplied in the preprocess metadata.
+//
 func (s ConstructionService) ConstructionMetadata(
 	ctx context.Context,
 	req *types.ConstructionMetadataRequest,
 ) (*types.ConstructionMetadataResponse, *types.Error) {
-	if s.config.IsOfflineMode() {
-		return nil, ErrUnavailableOffline
-	}
-
 	if s.pChainBackend.ShouldHandleRequest(req) {
 		return s.pChainBackend.ConstructionMetadata(ctx, req)
 	}
 
+	if s.config.IsOfflineMode() {
+		return nil, ErrUnavailableOffline
+	}
+
 	if s.cChainAtomicTxBackend.ShouldHandleRequest(req) {
 		return s.cChainAtomicTxBackend.ConstructionMetadata(ctx, req)
 	}
@@ -95,66 +163,339 @@ func (s ConstructionService) ConstructionMetadata(
 		return nil, WrapError(ErrInvalidInput, "from address is not provided")
 	}
 
+	// The gas price of an EIP-1559 transaction is its max fee per gas
+	var (
+		gasPrice, tip *big.Int
+		suggestions   *gasPrices
+		terr          *types.Error
+	)
+	if input.DynamicFee {
+		gasPrice, tip, terr = s.getDynamicFees(ctx, &input)
+	} else {
+		gasPrice, suggestions, terr = s.getGasPrice(ctx, &input)
+	}
+	if terr != nil {
+		return nil, terr
+	}
+
+	if len(input.Batch) > 0 {
+		if len(input.ReplaceTxHash) > 0 {
+			return nil, WrapError(ErrInvalidInput, errBatchReplacement)
+		}
+		return s.batchMetadata(ctx, &input, gasPrice, tip, suggestions)
+	}
+
+	if input.AccessList, terr = s.getAccessList(ctx, &input); terr != nil {
+		return nil, terr
+	}
+
 	var nonce uint64
-	var err error
-	if input.Nonce == nil {
-		nonce, err = s.client.NonceAt(ctx, ethcommon.HexToAddress(input.From), nil)
-		if err != nil {
-			return nil, WrapError(ErrClientError, err)
+	switch {
+	case len(input.ReplaceTxHash) > 0:
+		nonce, gasPrice, tip, terr = s.replacementMetadata(ctx, &input, gasPrice, tip)
+		if terr != nil {
+			return nil, terr
 		}
-	} else {
+	case input.Nonce == nil:
+		nonce, terr = s.getNonce(ctx, input.From, input.UsePendingNonce)
+		if terr != nil {
+			return nil, terr
+		}
+	default:
 		nonce = input.Nonce.Uint64()
 	}
 
+	gasLimit, terr := s.getGasLimit(ctx, &input)
+	if terr != nil {
+		return nil, terr
+	}
+
+	msg := callMsg(&input)
+	suggestedFee, terr := s.transactionFee(msg.To, msg.Data, gasPrice, gasLimit)
+	if terr != nil {
+		return nil, terr
+	}
+
+	metadata := &metadata{
+		Nonce:      nonce,
+		GasPrice:   gasPrice,
+		GasLimit:   gasLimit,
+		AccessList: input.AccessList,
+		GasPrices:  suggestions,
+	}
+	metadata.setDynamicFees(gasPrice, tip)
+
+	metadataMap, err := mapper.MarshalJSONMap(metadata)
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+
+	return &types.ConstructionMetadataResponse{
+		Metadata: metadataMap,
+		SuggestedFee: []*types.Amount{
+			mapper.AvaxAmount(suggestedFee),
+		},
+	}, nil
+}
+
+// getGasPrice returns the gas price set in input, or the suggested one
+// scaled by the suggested fee multiplier, along with the gas prices suggested
+// by the gas price oracle if there is one. The suggested gas price is the
+// oracle option picked in input, or the one of the node.
+func (s ConstructionService) getGasPrice(ctx context.Context, input *options) (*big.Int, *gasPrices, *types.Error) {
+	// The suggestions are always returned, so that an option can be picked
+	// in a later preprocess request
+	var suggestions *gasPrices
+	if s.gasPriceOracle != nil {
+		slow, normal, fast, err := s.gasPriceOracle.SuggestGasPrices(ctx)
+		if err != nil {
+			return nil, nil, WrapError(ErrClientError, err)
+		}
+		suggestions = &gasPrices{Slow: slow, Normal: normal, Fast: fast}
+	}
+
+	if input.GasPrice != nil {
+		return input.GasPrice, suggestions, nil
+	}
+
 	var gasPrice *big.Int
-	if input.GasPrice == nil {
-		if gasPrice, err = s.client.SuggestGasPrice(ctx); err != nil {
-			return nil, WrapError(ErrClientError, err)
+	if len(input.GasPriceOption) > 0 {
+		if suggestions == nil {
+			return nil, nil, WrapError(ErrInvalidInput, errNoGasPriceOracle)
 		}
 
-		if input.SuggestedFeeMultiplier != nil {
-			newGasPrice := new(big.Float).Mul(
-				big.NewFloat(*input.SuggestedFeeMultiplier),
-				new(big.Float).SetInt(gasPrice),
-			)
-			newGasPrice.Int(gasPrice)
+		option, ok := suggestions.option(input.GasPriceOption)
+		if !ok {
+			return nil, nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid gas price option", input.GasPriceOption))
 		}
+		gasPrice = new(big.Int).Set(option)
 	} else {
-		gasPrice = input.GasPrice
+		var err error
+		if gasPrice, err = s.client.SuggestGasPrice(ctx); err != nil {
+			return nil, nil, WrapError(ErrClientError, err)
+		}
 	}
 
-	var gasLimit uint64
-	if input.GasLimit == nil {
-		if input.Currency == nil || utils.Equal(input.Currency, mapper.AvaxCurrency) {
-			gasLimit, err = s.getNativeTransferGasLimit(ctx, input.To, input.From, input.Value)
-		} else {
-			gasLimit, err = s.getErc20TransferGasLimit(ctx, input.To, input.From, input.Value, input.Currency)
+	if input.SuggestedFeeMultiplier != nil {
+		gasPrice, _ = new(big.Float).Mul(
+			big.NewFloat(*input.SuggestedFeeMultiplier),
+			new(big.Float).SetInt(gasPrice),
+		).Int(nil)
+	}
+
+	return gasPrice, suggestions, nil
+}
+
+// getDynamicFees returns the max fee and the max priority fee per gas of an
+// EIP-1559 transaction. The max priority fee defaults to the one suggested by
+// the node, scaled by the suggested fee multiplier, and the max fee to twice
+// the base fee plus the max priority fee, so that the transaction remains
+// includable while the base fee rises.
+func (s ConstructionService) getDynamicFees(ctx context.Context, input *options) (*big.Int, *big.Int, *types.Error) {
+	tip := input.MaxPriorityFeePerGas
+	if tip == nil {
+		suggested, err := s.client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, nil, WrapError(ErrClientError, err)
+		}
+		tip = suggested
+		if input.SuggestedFeeMultiplier != nil {
+			tip, _ = new(big.Float).Mul(
+				big.NewFloat(*input.SuggestedFeeMultiplier),
+				new(big.Float).SetInt(tip),
+			).Int(nil)
 		}
+	}
 
+	maxFee := input.MaxFeePerGas
+	if maxFee == nil {
+		baseFee, err := s.client.EstimateBaseFee(ctx)
 		if err != nil {
-			return nil, WrapError(ErrClientError, err)
+			return nil, nil, WrapError(ErrClientError, err)
 		}
-	} else {
-		gasLimit = input.GasLimit.Uint64()
+		maxFee = new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tip)
 	}
 
-	metadata := &metadata{
-		Nonce:    nonce,
-		GasPrice: gasPrice,
-		GasLimit: gasLimit,
+	if maxFee.Cmp(tip) < 0 {
+		return nil, nil, WrapError(ErrInvalidInput, fmt.Errorf("%w: %s < %s", errMaxFeeBelowTip, maxFee, tip))
 	}
 
-	metadataMap, err := mapper.MarshalJSONMap(metadata)
+	return maxFee, tip, nil
+}
+
+// getAccessList returns the access list set in input or, if requested, the
+// one the node generates for the transaction input describes
+func (s ConstructionService) getAccessList(ctx context.Context, input *options) (ethtypes.AccessList, *types.Error) {
+	if !input.CreateAccessList || input.AccessList != nil {
+		return input.AccessList, nil
+	}
+
+	accessList, _, err := s.client.CreateAccessList(ctx, callMsg(input))
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+	return *accessList, nil
+}
+
+// transactionFee returns the fee of a transaction sent to to with data,
+// after checking it against the max fee of the currency it transfers
+func (s ConstructionService) transactionFee(
+	to *ethcommon.Address,
+	data []byte,
+	gasPrice *big.Int,
+	gasLimit uint64,
+) (*big.Int, *types.Error) {
+	fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+
+	// Native transfers and deployments transfer AVAX, other transactions
+	// call a token contract
+	key := mapper.AvaxCurrency.Symbol
+	if to != nil && len(data) > 0 {
+		key = to.Hex()
+	}
+	if maxFee := s.config.MaxFee(key); maxFee != nil && fee.Cmp(maxFee) > 0 {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%w of %s: %s > %s", errMaxFeeExceeded, key, fee, maxFee))
+	}
+
+	return fee, nil
+}
+
+// replacementMetadata returns the nonce of the pending transaction replaced by
+// input, and a gas price outbidding it by at least the configured bump. The
+// gas price of an EIP-1559 replacement is its max fee per gas, and its max
+// priority fee per gas tip is bumped as well.
+func (s ConstructionService) replacementMetadata(
+	ctx context.Context,
+	input *options,
+	gasPrice *big.Int,
+	tip *big.Int,
+) (uint64, *big.Int, *big.Int, *types.Error) {
+	hash, err := hexutil.Decode(input.ReplaceTxHash)
+	if err != nil || len(hash) != ethcommon.HashLength {
+		return 0, nil, nil, WrapError(ErrInvalidInput, errInvalidReplaceTxHash)
+	}
+
+	tx, pending, err := s.client.TransactionByHash(ctx, ethcommon.BytesToHash(hash))
+	if err != nil {
+		return 0, nil, nil, WrapError(ErrClientError, err)
+	}
+	if !pending {
+		return 0, nil, nil, WrapError(ErrInvalidInput, errReplacedTxNotPending)
+	}
+
+	sender, err := ethtypes.Sender(s.config.Signer(), tx)
+	if err != nil {
+		return 0, nil, nil, WrapError(ErrInternalError, err)
+	}
+	if sender != ethcommon.HexToAddress(input.From) {
+		return 0, nil, nil, WrapError(ErrInvalidInput, errReplacedTxSender)
+	}
+
+	// The transaction may already have been replaced, in which case the one
+	// pooled at its nonce is the one to outbid
+	replacedGasPrice := tx.GasPrice()
+	content, err := s.client.TxPoolContent(ctx)
+	if err != nil {
+		return 0, nil, nil, WrapError(ErrClientError, err)
+	}
+	if pooled, ok := pooledGasPrice(content, sender, tx.Nonce()); ok && pooled.Cmp(replacedGasPrice) > 0 {
+		replacedGasPrice = pooled
+	}
+
+	if minGasPrice := s.bumpedFee(replacedGasPrice); gasPrice.Cmp(minGasPrice) < 0 {
+		gasPrice = minGasPrice
+	}
+	if minTip := s.bumpedFee(tx.GasTipCap()); tip != nil && tip.Cmp(minTip) < 0 {
+		tip = minTip
+	}
+
+	return tx.Nonce(), gasPrice, tip, nil
+}
+
+// bumpedFee returns fee increased by the replacement fee bump
+func (s ConstructionService) bumpedFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+s.config.ReplacementFeeBump()))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// batchMetadata allocates sequential nonces to the transfers of a batch, per
+// sender, and estimates the gas limit of each of them. The access list of the
+// batch applies to every transfer, and generated ones are made per transfer.
+func (s ConstructionService) batchMetadata(
+	ctx context.Context,
+	input *options,
+	gasPrice *big.Int,
+	tip *big.Int,
+	suggestions *gasPrices,
+) (*types.ConstructionMetadataResponse, *types.Error) {
+	if len(input.Batch) > maxBatchTransfers {
+		return nil, WrapError(ErrInvalidInput, errBatchTooLarge)
+	}
+
+	nonces := map[string]uint64{}
+	if input.Nonce != nil {
+		nonces[input.From] = input.Nonce.Uint64()
+	}
+	// Reserved nonces are distinct, so every transfer reserves its own
+	reserving := input.Nonce == nil && input.UsePendingNonce && s.nonceReserver != nil
+
+	suggestedFee := new(big.Int)
+	batch := make([]*metadata, 0, len(input.Batch))
+	for _, transfer := range input.Batch {
+		nonce, ok := nonces[transfer.From]
+		if !ok || reserving {
+			var terr *types.Error
+			nonce, terr = s.getNonce(ctx, transfer.From, input.UsePendingNonce)
+			if terr != nil {
+				return nil, terr
+			}
+		}
+		nonces[transfer.From] = nonce + 1
+
+		transfer.AccessList = input.AccessList
+		transfer.CreateAccessList = input.CreateAccessList
+		accessList, terr := s.getAccessList(ctx, transfer)
+		if terr != nil {
+			return nil, terr
+		}
+		transfer.AccessList = accessList
+
+		transfer.GasLimit = input.GasLimit
+		gasLimit, terr := s.getGasLimit(ctx, transfer)
+		if terr != nil {
+			return nil, terr
+		}
+
+		msg := callMsg(transfer)
+		fee, terr := s.transactionFee(msg.To, msg.Data, gasPrice, gasLimit)
+		if terr != nil {
+			return nil, terr
+		}
+
+		transferMetadata := &metadata{
+			Nonce:      nonce,
+			GasPrice:   gasPrice,
+			GasLimit:   gasLimit,
+			AccessList: accessList,
+		}
+		transferMetadata.setDynamicFees(gasPrice, tip)
+		batch = append(batch, transferMetadata)
+		suggestedFee.Add(suggestedFee, fee)
+	}
+
+	// The first transfer doubles as the metadata of the whole batch
+	metadata := *batch[0]
+	metadata.GasPrices = suggestions
+	metadata.Batch = batch
+
+	metadataMap, err := mapper.MarshalJSONMap(&metadata)
 	if err != nil {
 		return nil, WrapError(ErrInternalError, err)
 	}
 
-	suggestedFee := gasPrice.Int64() * int64(gasLimit)
 	return &types.ConstructionMetadataResponse{
-		Metadata: metadataMap,
-		SuggestedFee: []*types.Amount{
-			mapper.AvaxAmount(big.NewInt(suggestedFee)),
-		},
+		Metadata:     metadataMap,
+		SuggestedFee: []*types.Amount{mapper.AvaxAmount(suggestedFee)},
 	}, nil
 }
 
@@ -178,21 +519,21 @@ func (s ConstructionService) ConstructionHash(
 		return s.cChainAtomicTxBackend.ConstructionHash(ctx, req)
 	}
 
-	var wrappedTx signedTransactionWrapper
-	if err := json.Unmarshal([]byte(req.SignedTransaction), &wrappedTx); err != nil {
+	wrappedTxs, err := decodeSignedTransactions(req.SignedTransaction)
+	if err != nil {
 		return nil, WrapError(ErrInvalidInput, err)
 	}
 
-	var signedTx ethtypes.Transaction
-	if err := signedTx.UnmarshalJSON(wrappedTx.SignedTransaction); err != nil {
-		return nil, WrapError(ErrInvalidInput, err)
+	hashes := make([]string, 0, len(wrappedTxs))
+	for _, wrappedTx := range wrappedTxs {
+		signedTx, err := wrappedTx.transaction()
+		if err != nil {
+			return nil, WrapError(ErrInvalidInput, err)
+		}
+		hashes = append(hashes, signedTx.Hash().Hex())
 	}
 
-	return &types.TransactionIdentifierResponse{
-		TransactionIdentifier: &types.TransactionIdentifier{
-			Hash: signedTx.Hash().Hex(),
-		},
-	}, nil
+	return batchTransactionIdentifier(hashes), nil
 }
 
 // ConstructionCombine implements /construction/combine endpoint.
@@ -220,40 +561,43 @@ func (s ConstructionService) ConstructionCombine(
 		return s.cChainAtomicTxBackend.ConstructionCombine(ctx, req)
 	}
 
-	var unsignedTx transaction
-	if err := json.Unmarshal([]byte(req.UnsignedTransaction), &unsignedTx); err != nil {
-		return nil, WrapError(ErrInvalidInput, err)
-	}
-
-	ethTransaction := ethtypes.NewTransaction(
-		unsignedTx.Nonce,
-		ethcommon.HexToAddress(unsignedTx.To),
-		unsignedTx.Value,
-		unsignedTx.GasLimit,
-		unsignedTx.GasPrice,
-		unsignedTx.Data,
-	)
-
-	signer := ethtypes.LatestSignerForChainID(unsignedTx.ChainID)
-	signedTx, err := ethTransaction.WithSignature(signer, req.Signatures[0].Bytes)
+	unsignedTxs, err := decodeUnsignedTransactions(req.UnsignedTransaction)
 	if err != nil {
 		return nil, WrapError(ErrInvalidInput, err)
 	}
 
-	signedTxJSON, err := signedTx.MarshalJSON()
-	if err != nil {
-		return nil, WrapError(ErrInternalError, err)
+	var signed interface{}
+	if len(unsignedTxs) == 1 {
+		wrappedSignedTx, terr := signTransaction(unsignedTxs[0], req.Signatures[0])
+		if terr != nil {
+			return nil, terr
+		}
+		signed = wrappedSignedTx
+	} else {
+		// Signatures are matched to the transactions by their payload
+		batch := &signedBatchTransaction{}
+		for _, unsignedTx := range unsignedTxs {
+			signature, err := findSignature(unsignedTx, req.Signatures)
+			if err != nil {
+				return nil, WrapError(ErrInvalidInput, err)
+			}
+
+			wrappedSignedTx, terr := signTransaction(unsignedTx, signature)
+			if terr != nil {
+				return nil, terr
+			}
+			batch.SignedTransactions = append(batch.SignedTransactions, wrappedSignedTx)
+		}
+		signed = batch
 	}
 
-	wrappedSignedTx := signedTransactionWrapper{SignedTransaction: signedTxJSON, Currency: unsignedTx.Currency}
-
-	wrappedSignedTxJSON, err := json.Marshal(wrappedSignedTx)
+	signedJSON, err := json.Marshal(signed)
 	if err != nil {
 		return nil, WrapError(ErrInternalError, err)
 	}
 
 	return &types.ConstructionCombineResponse{
-		SignedTransaction: string(wrappedSignedTxJSON),
+		SignedTransaction: string(signedJSON),
 	}, nil
 }
 
@@ -269,6 +613,10 @@ func (s ConstructionService) ConstructionDerive(
 	if req.PublicKey == nil {
 		return nil, WrapError(ErrInvalidInput, "public key is not provided")
 	}
+	// Derive is only supported for secp256k1 keys on every chain
+	if err := mapper.ValidateCurveType(req.PublicKey.CurveType); err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
 
 	if s.pChainBackend.ShouldHandleRequest(req) {
 		return s.pChainBackend.ConstructionDerive(ctx, req)
@@ -278,14 +626,31 @@ func (s ConstructionService) ConstructionDerive(
 		return s.cChainAtomicTxBackend.ConstructionDerive(ctx, req)
 	}
 
-	key, err := ethcrypto.DecompressPubkey(req.PublicKey.Bytes)
+	key, err := mapper.ParsePublicKey(req.PublicKey)
+	if err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+
+	if req.Metadata[mapper.MetaAddressFormat] != mapper.AddressFormatAll {
+		return &types.ConstructionDeriveResponse{
+			AccountIdentifier: &types.AccountIdentifier{
+				Address: ethcrypto.PubkeyToAddress(*key).Hex(),
+			},
+		}, nil
+	}
+
+	addresses, err := mapper.DeriveAddresses(req.NetworkIdentifier, key)
 	if err != nil {
 		return nil, WrapError(ErrInvalidInput, err)
 	}
 
 	return &types.ConstructionDeriveResponse{
 		AccountIdentifier: &types.AccountIdentifier{
-			Address: ethcrypto.PubkeyToAddress(*key).Hex(),
+			Address: addresses[mapper.AddressFormatHex],
+		},
+		Metadata: map[string]interface{}{
+			mapper.MetaAddressFormat: mapper.AddressFormatAll,
+			mapper.MetaAddresses:     addresses,
 		},
 	}, nil
 }
@@ -308,38 +673,138 @@ func (s ConstructionService) ConstructionParse(
 		return s.cChainAtomicTxBackend.ConstructionParse(ctx, req)
 	}
 
-	var tx transaction
-	if !req.Signed {
-		if err := json.Unmarshal([]byte(req.Transaction), &tx); err != nil {
-			return nil, WrapError(ErrInvalidInput, err)
-		}
+	var txs []*transaction
+	var err error
+	if req.Signed {
+		txs, err = s.decodeSignedTransfers(req.Transaction)
 	} else {
-		var wrappedTx signedTransactionWrapper
-		if err := json.Unmarshal([]byte(req.Transaction), &wrappedTx); err != nil {
-			return nil, WrapError(ErrInvalidInput, err)
+		txs, err = decodeUnsignedTransactions(req.Transaction)
+	}
+	if err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+
+	ops := []*types.Operation{}
+	signers := []*types.AccountIdentifier{}
+	batch := make([]map[string]interface{}, 0, len(txs))
+	for _, tx := range txs {
+		// Ensure valid from address
+		checkFrom, ok := ChecksumAddress(tx.From)
+		if !ok {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", tx.From))
 		}
 
-		var t ethtypes.Transaction
-		if err := t.UnmarshalJSON(wrappedTx.SignedTransaction); err != nil {
+		var txOps []*types.Operation
+		var contractAddress string
+		switch {
+		case len(tx.To) == 0:
+			txOps = deploymentOps(tx, checkFrom)
+			contractAddress = ethcrypto.CreateAddress(ethcommon.HexToAddress(checkFrom), tx.Nonce).Hex()
+		case isErc721TransferData(tx.Data):
+			txOps, err = erc721TransferOps(tx)
+		default:
+			txOps, err = transferOps(tx, checkFrom)
+		}
+		if err != nil {
 			return nil, WrapError(ErrInvalidInput, err)
 		}
+		ops = append(ops, offsetOperations(txOps, int64(len(ops)))...)
+
+		if !containsSigner(signers, checkFrom) {
+			signers = append(signers, &types.AccountIdentifier{Address: checkFrom})
+		}
+
+		metaMap, err := mapper.MarshalJSONMap(&parseMetadata{
+			Nonce:           tx.Nonce,
+			GasPrice:        tx.GasPrice,
+			GasLimit:        tx.GasLimit,
+			ChainID:         tx.ChainID,
+			ContractAddress: contractAddress,
+			AccessList:      tx.AccessList,
+
+			MaxFeePerGas:         tx.MaxFeePerGas,
+			MaxPriorityFeePerGas: tx.MaxPriorityFeePerGas,
+		})
+		if err != nil {
+			return nil, WrapError(ErrInternalError, err)
+		}
+		batch = append(batch, metaMap)
+	}
+
+	// The first transaction doubles as the metadata of the whole batch
+	metaMap := map[string]interface{}{}
+	for k, v := range batch[0] {
+		metaMap[k] = v
+	}
+	if len(batch) > 1 {
+		metaMap["batch"] = batch
+	}
+
+	if req.Signed {
+		return &types.ConstructionParseResponse{
+			Operations:               ops,
+			AccountIdentifierSigners: signers,
+			Metadata:                 metaMap,
+		}, nil
+	}
+
+	return &types.ConstructionParseResponse{
+		Operations:               ops,
+		AccountIdentifierSigners: []*types.AccountIdentifier{},
+		Metadata:                 metaMap,
+	}, nil
+}
+
+// decodeSignedTransfers returns the transfers of a signed transaction, or of
+// a signed batch, in the format of unsigned transactions
+func (s ConstructionService) decodeSignedTransfers(signed string) ([]*transaction, error) {
+	wrappedTxs, err := decodeSignedTransactions(signed)
+	if err != nil {
+		return nil, err
+	}
 
-		tx.To = t.To().String()
-		tx.Value = t.Value()
-		tx.Data = t.Data()
-		tx.Nonce = t.Nonce()
-		tx.GasPrice = t.GasPrice()
-		tx.GasLimit = t.Gas()
-		tx.ChainID = s.config.ChainID
-		tx.Currency = wrappedTx.Currency
+	txs := make([]*transaction, 0, len(wrappedTxs))
+	for _, wrappedTx := range wrappedTxs {
+		t, err := wrappedTx.transaction()
+		if err != nil {
+			return nil, err
+		}
 
 		msg, err := t.AsMessage(s.config.Signer(), nil)
 		if err != nil {
-			return nil, WrapError(ErrInvalidInput, err)
+			return nil, err
 		}
-		tx.From = msg.From().Hex()
+
+		// Contract deployments have no recipient
+		var to string
+		if t.To() != nil {
+			to = t.To().String()
+		}
+
+		tx := &transaction{
+			From:       msg.From().Hex(),
+			To:         to,
+			Value:      t.Value(),
+			Data:       t.Data(),
+			Nonce:      t.Nonce(),
+			GasPrice:   t.GasPrice(),
+			GasLimit:   t.Gas(),
+			ChainID:    s.config.ChainID,
+			Currency:   wrappedTx.Currency,
+			AccessList: t.AccessList(),
+		}
+		if t.Type() == ethtypes.DynamicFeeTxType {
+			tx.MaxFeePerGas = t.GasFeeCap()
+			tx.MaxPriorityFeePerGas = t.GasTipCap()
+		}
+		txs = append(txs, tx)
 	}
 
+	return txs, nil
+}
+
+// transferOps returns the operations of a native or ERC-20 transfer
+func transferOps(tx *transaction, checkFrom string) ([]*types.Operation, error) {
 	var opMethod string
 	var value *big.Int
 	var toAddressHex string
@@ -347,7 +812,7 @@ func (s ConstructionService) ConstructionParse(
 	if len(tx.Data) != 0 {
 		toAddress, amountSent, err := parseErc20TransferData(tx.Data)
 		if err != nil {
-			return nil, WrapError(ErrInvalidInput, err)
+			return nil, err
 		}
 
 		value = amountSent
@@ -359,19 +824,13 @@ func (s ConstructionService) ConstructionParse(
 		toAddressHex = tx.To
 	}
 
-	// Ensure valid from address
-	checkFrom, ok := ChecksumAddress(tx.From)
-	if !ok {
-		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", tx.From))
-	}
-
 	// Ensure valid to address
 	checkTo, ok := ChecksumAddress(toAddressHex)
 	if !ok {
-		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", tx.To))
+		return nil, fmt.Errorf("%s is not a valid address", tx.To)
 	}
 
-	ops := []*types.Operation{
+	return []*types.Operation{
 		{
 			Type: opMethod,
 			OperationIdentifier: &types.OperationIdentifier{
@@ -403,37 +862,68 @@ func (s ConstructionService) ConstructionParse(
 				Currency: tx.Currency,
 			},
 		},
-	}
+	}, nil
+}
 
-	metadata := &parseMetadata{
-		Nonce:    tx.Nonce,
-		GasPrice: tx.GasPrice,
-		GasLimit: tx.GasLimit,
-		ChainID:  tx.ChainID,
+// deploymentOps returns the operation of a contract deployment, in the
+// format of the intent it was built from
+func deploymentOps(tx *transaction, checkFrom string) []*types.Operation {
+	return []*types.Operation{
+		{
+			Type: mapper.OpCreate,
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: 0,
+			},
+			Account: &types.AccountIdentifier{
+				Address: checkFrom,
+			},
+			Amount: mapper.AvaxAmount(new(big.Int).Neg(tx.Value)),
+		},
 	}
-	metaMap, err := mapper.MarshalJSONMap(metadata)
+}
+
+// erc721TransferOps returns the operations of an ERC-721 transfer, in the
+// format of the ones emitted by the data API
+func erc721TransferOps(tx *transaction) ([]*types.Operation, error) {
+	fromAddress, toAddress, tokenID, err := parseErc721TransferData(tx.Data)
 	if err != nil {
-		return nil, WrapError(ErrInternalError, err)
+		return nil, err
 	}
 
-	if req.Signed {
-		return &types.ConstructionParseResponse{
-			Operations: ops,
-			AccountIdentifierSigners: []*types.AccountIdentifier{
+	checkContract, ok := ChecksumAddress(tx.To)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a valid address", tx.To)
+	}
+
+	metadata := map[string]interface{}{
+		mapper.ContractAddressMetadata:  checkContract,
+		mapper.IndexTransferredMetadata: ethcommon.BigToHash(tokenID).String(),
+	}
+
+	return []*types.Operation{
+		{
+			Type: mapper.OpErc721TransferSender,
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: 0,
+			},
+			Account:  mapper.Account(fromAddress),
+			Metadata: metadata,
+		},
+		{
+			Type: mapper.OpErc721TransferReceive,
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: 1,
+			},
+			RelatedOperations: []*types.OperationIdentifier{
 				{
-					Address: checkFrom,
+					Index: 0,
 				},
 			},
-			Metadata: metaMap,
-		}, nil
-	}
-
-	return &types.ConstructionParseResponse{
-		Operations:               ops,
-		AccountIdentifierSigners: []*types.AccountIdentifier{},
-		Metadata:                 metaMap,
-	}, nil
-}
+			Account:  mapper.Account(toAddress),
+			Metadata: metadata,
+		},
+	}, nil
+}
 
 // ConstructionPayloads implements /construction/payloads endpoint
 //
@@ -459,26 +949,109 @@ func (s ConstructionService) ConstructionPayloads(
 		return s.cChainAtomicTxBackend.ConstructionPayloads(ctx, req)
 	}
 
-	operationDescriptions, err := s.CreateOperationDescription(req.Operations)
-	if err != nil {
+	var metadata metadata
+	if err := mapper.UnmarshalJSONMap(req.Metadata, &metadata); err != nil {
 		return nil, WrapError(ErrInvalidInput, err)
 	}
 
-	descriptions := &parser.Descriptions{
-		OperationDescriptions: operationDescriptions,
-		ErrUnmatched:          true,
+	if isContractDeployment(req.Operations) {
+		return s.deploymentPayloads(req.Operations[0], &metadata)
+	}
+
+	transfers, terr := s.matchTransfers(req.Operations)
+	if terr != nil {
+		return nil, terr
 	}
 
-	matches, err := parser.MatchOperations(descriptions, req.Operations)
+	var unsigned interface{}
+	var payloads []*types.SigningPayload
+	if len(transfers) == 1 {
+		unsignedTx, payload, terr := s.buildTransfer(transfers[0], &metadata)
+		if terr != nil {
+			return nil, terr
+		}
+		unsigned = unsignedTx
+		payloads = []*types.SigningPayload{payload}
+	} else {
+		if len(metadata.Batch) != len(transfers) {
+			return nil, WrapError(ErrInvalidInput, errBatchMetadataMismatch)
+		}
+
+		batch := &batchTransaction{}
+		for i, matches := range transfers {
+			unsignedTx, payload, terr := s.buildTransfer(matches, metadata.Batch[i])
+			if terr != nil {
+				return nil, terr
+			}
+			batch.Transactions = append(batch.Transactions, unsignedTx)
+			payloads = append(payloads, payload)
+		}
+		unsigned = batch
+	}
+
+	unsignedJSON, err := json.Marshal(unsigned)
 	if err != nil {
-		return nil, WrapError(ErrInvalidInput, "unclear intent")
+		return nil, WrapError(ErrInternalError, err)
 	}
 
-	var metadata metadata
-	if err := mapper.UnmarshalJSONMap(req.Metadata, &metadata); err != nil {
+	return &types.ConstructionPayloadsResponse{
+		UnsignedTransaction: string(unsignedJSON),
+		Payloads:            payloads,
+	}, nil
+}
+
+// deploymentPayloads builds the unsigned transaction of a contract
+// deployment and the payload to sign
+func (s ConstructionService) deploymentPayloads(
+	operation *types.Operation,
+	metadata *metadata,
+) (*types.ConstructionPayloadsResponse, *types.Error) {
+	from, value, data, err := parseDeploymentOperation(operation)
+	if err != nil {
 		return nil, WrapError(ErrInvalidInput, err)
 	}
 
+	unsignedTx := &transaction{
+		From:       from,
+		Value:      value,
+		Data:       data,
+		Nonce:      metadata.Nonce,
+		GasPrice:   metadata.GasPrice,
+		GasLimit:   metadata.GasLimit,
+		ChainID:    s.config.ChainID,
+		Currency:   mapper.AvaxCurrency,
+		AccessList: metadata.AccessList,
+
+		MaxFeePerGas:         metadata.MaxFeePerGas,
+		MaxPriorityFeePerGas: metadata.MaxPriorityFeePerGas,
+	}
+	if _, terr := s.transactionFee(nil, data, unsignedTx.GasPrice, unsignedTx.GasLimit); terr != nil {
+		return nil, terr
+	}
+
+	unsignedJSON, err := json.Marshal(unsignedTx)
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+
+	return &types.ConstructionPayloadsResponse{
+		UnsignedTransaction: string(unsignedJSON),
+		Payloads: []*types.SigningPayload{
+			{
+				AccountIdentifier: &types.AccountIdentifier{Address: from},
+				Bytes:             s.config.Signer().Hash(unsignedTx.ethTransaction()).Bytes(),
+				SignatureType:     types.EcdsaRecovery,
+			},
+		},
+	}, nil
+}
+
+// buildTransfer builds the unsigned transaction of a matched transfer and the
+// payload to sign
+func (s ConstructionService) buildTransfer(
+	matches []*parser.Match,
+	metadata *metadata,
+) (*transaction, *types.SigningPayload, *types.Error) {
 	toOp, amount := matches[1].First()
 	toAddress := toOp.Account.Address
 	nonce := metadata.Nonce
@@ -488,26 +1061,39 @@ func (s ConstructionService) ConstructionPayloads(
 
 	fromOp, _ := matches[0].First()
 	fromAddress := fromOp.Account.Address
-	fromCurrency := fromOp.Amount.Currency
+	var fromCurrency *types.Currency
+	if fromOp.Amount != nil {
+		fromCurrency = fromOp.Amount.Currency
+	}
 
 	checkFrom, ok := ChecksumAddress(fromAddress)
 	if !ok {
-		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", fromAddress))
+		return nil, nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", fromAddress))
 	}
 
 	checkTo, ok := ChecksumAddress(toAddress)
 	if !ok {
-		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", toAddress))
+		return nil, nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", toAddress))
 	}
 	var transferData []byte
 	var sendToAddress ethcommon.Address
-	if utils.Equal(fromCurrency, mapper.AvaxCurrency) {
+	switch {
+	case fromOp.Type == mapper.OpErc721TransferSender:
+		contract, tokenID, err := parseErc721TransferOperations(fromOp, toOp)
+		if err != nil {
+			return nil, nil, WrapError(ErrInvalidInput, err)
+		}
+
+		transferData = generateErc721TransferData(checkFrom, checkTo, tokenID)
+		sendToAddress = ethcommon.HexToAddress(contract)
+		amount = big.NewInt(0)
+	case utils.Equal(fromCurrency, mapper.AvaxCurrency):
 		transferData = []byte{}
 		sendToAddress = ethcommon.HexToAddress(checkTo)
-	} else {
+	default:
 		contract, ok := fromCurrency.Metadata[mapper.ContractAddressMetadata].(string)
 		if !ok {
-			return nil, WrapError(ErrInvalidInput,
+			return nil, nil, WrapError(ErrInvalidInput,
 				fmt.Errorf("%s currency doesn't have a contract address in metadata", fromCurrency.Symbol))
 		}
 
@@ -516,42 +1102,32 @@ func (s ConstructionService) ConstructionPayloads(
 		amount = big.NewInt(0)
 	}
 
-	tx := ethtypes.NewTransaction(
-		nonce,
-		sendToAddress,
-		amount,
-		gasLimit,
-		gasPrice,
-		transferData,
-	)
-
 	unsignedTx := &transaction{
-		From:     checkFrom,
-		To:       sendToAddress.Hex(),
-		Value:    amount,
-		Data:     tx.Data(),
-		Nonce:    tx.Nonce(),
-		GasPrice: gasPrice,
-		GasLimit: tx.Gas(),
-		ChainID:  chainID,
-		Currency: fromCurrency,
+		From:       checkFrom,
+		To:         sendToAddress.Hex(),
+		Value:      amount,
+		Data:       transferData,
+		Nonce:      nonce,
+		GasPrice:   gasPrice,
+		GasLimit:   gasLimit,
+		ChainID:    chainID,
+		Currency:   fromCurrency,
+		AccessList: metadata.AccessList,
+
+		MaxFeePerGas:         metadata.MaxFeePerGas,
+		MaxPriorityFeePerGas: metadata.MaxPriorityFeePerGas,
+	}
+	if _, terr := s.transactionFee(&sendToAddress, transferData, gasPrice, gasLimit); terr != nil {
+		return nil, nil, terr
 	}
 
 	payload := &types.SigningPayload{
 		AccountIdentifier: &types.AccountIdentifier{Address: checkFrom},
-		Bytes:             s.config.Signer().Hash(tx).Bytes(),
+		Bytes:             s.config.Signer().Hash(unsignedTx.ethTransaction()).Bytes(),
 		SignatureType:     types.EcdsaRecovery,
 	}
 
-	unsignedTxJSON, err := json.Marshal(unsignedTx)
-	if err != nil {
-		return nil, WrapError(ErrInternalError, err)
-	}
-
-	return &types.ConstructionPayloadsResponse{
-		UnsignedTransaction: string(unsignedTxJSON),
-		Payloads:            []*types.SigningPayload{payload},
-	}, nil
+	return unsignedTx, payload, nil
 }
 
 // ConstructionPreprocess implements /construction/preprocess endpoint.
@@ -571,44 +1147,40 @@ func (s ConstructionService) ConstructionPreprocess(
 		return s.cChainAtomicTxBackend.ConstructionPreprocess(ctx, req)
 	}
 
-	operationDescriptions, err := s.CreateOperationDescription(req.Operations)
-	if err != nil {
-		return nil, WrapError(ErrInvalidInput, err)
-	}
-
-	descriptions := &parser.Descriptions{
-		OperationDescriptions: operationDescriptions,
-		ErrUnmatched:          true,
-	}
-
-	matches, err := parser.MatchOperations(descriptions, req.Operations)
-	if err != nil {
-		return nil, WrapError(ErrInvalidInput, "unclear intent")
-	}
-
-	fromOp, _ := matches[0].First()
-	fromAddress := fromOp.Account.Address
-	toOp, amount := matches[1].First()
-	toAddress := toOp.Account.Address
-
-	fromCurrency := fromOp.Amount.Currency
+	var preprocessOptions options
+	if isContractDeployment(req.Operations) {
+		from, value, data, err := parseDeploymentOperation(req.Operations[0])
+		if err != nil {
+			return nil, WrapError(ErrInvalidInput, err)
+		}
+		preprocessOptions = options{
+			From:     from,
+			Value:    value,
+			Currency: mapper.AvaxCurrency,
+			Data:     data,
+		}
+	} else {
+		transfers, terr := s.matchTransfers(req.Operations)
+		if terr != nil {
+			return nil, terr
+		}
 
-	checkFrom, ok := ChecksumAddress(fromAddress)
-	if !ok {
-		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", fromAddress))
-	}
-	checkTo, ok := ChecksumAddress(toAddress)
-	if !ok {
-		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", toAddress))
-	}
+		batch := make([]*options, 0, len(transfers))
+		for _, matches := range transfers {
+			transferOptions, terr := transferOptions(matches)
+			if terr != nil {
+				return nil, terr
+			}
+			batch = append(batch, transferOptions)
+		}
 
-	preprocessOptions := &options{
-		From:                   checkFrom,
-		To:                     checkTo,
-		Value:                  amount,
-		SuggestedFeeMultiplier: req.SuggestedFeeMultiplier,
-		Currency:               fromCurrency,
+		// The first transfer doubles as the options of the whole batch
+		preprocessOptions = *batch[0]
+		if len(batch) > 1 {
+			preprocessOptions.Batch = batch
+		}
 	}
+	preprocessOptions.SuggestedFeeMultiplier = req.SuggestedFeeMultiplier
 
 	if v, ok := req.Metadata["gas_price"]; ok {
 		stringObj, ok := v.(string)
@@ -621,6 +1193,37 @@ func (s ConstructionService) ConstructionPreprocess(
 		}
 		preprocessOptions.GasPrice = bigObj
 	}
+	if v, ok := req.Metadata["max_fee_per_gas"]; ok {
+		stringObj, ok := v.(string)
+		if !ok {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid max fee per gas string", v))
+		}
+		bigObj, ok := new(big.Int).SetString(stringObj, 10)
+		if !ok {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid max fee per gas", v))
+		}
+		preprocessOptions.MaxFeePerGas = bigObj
+		preprocessOptions.DynamicFee = true
+	}
+	if v, ok := req.Metadata["max_priority_fee_per_gas"]; ok {
+		stringObj, ok := v.(string)
+		if !ok {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid max priority fee per gas string", v))
+		}
+		bigObj, ok := new(big.Int).SetString(stringObj, 10)
+		if !ok {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid max priority fee per gas", v))
+		}
+		preprocessOptions.MaxPriorityFeePerGas = bigObj
+		preprocessOptions.DynamicFee = true
+	}
+	if v, ok := req.Metadata["dynamic_fee"]; ok {
+		boolObj, ok := v.(bool)
+		if !ok {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%v is not a valid dynamic_fee bool", v))
+		}
+		preprocessOptions.DynamicFee = preprocessOptions.DynamicFee || boolObj
+	}
 	if v, ok := req.Metadata["gas_limit"]; ok {
 		stringObj, ok := v.(string)
 		if !ok {
@@ -632,6 +1235,46 @@ func (s ConstructionService) ConstructionPreprocess(
 		}
 		preprocessOptions.GasLimit = bigObj
 	}
+	if v, ok := req.Metadata["replace_tx_hash"]; ok {
+		stringObj, ok := v.(string)
+		if !ok {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid transaction hash string", v))
+		}
+		preprocessOptions.ReplaceTxHash = stringObj
+	}
+	if v, ok := req.Metadata["access_list"]; ok {
+		accessList, err := parseAccessList(v)
+		if err != nil {
+			return nil, WrapError(ErrInvalidInput, err)
+		}
+		preprocessOptions.AccessList = accessList
+	}
+	if v, ok := req.Metadata["create_access_list"]; ok {
+		boolObj, ok := v.(bool)
+		if !ok {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%v is not a valid create_access_list bool", v))
+		}
+		preprocessOptions.CreateAccessList = boolObj
+	}
+	if v, ok := req.Metadata["gas_price_option"]; ok {
+		stringObj, ok := v.(string)
+		if !ok {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%v is not a valid gas_price_option string", v))
+		}
+		switch stringObj {
+		case gasPriceSlow, gasPriceNormal, gasPriceFast:
+		default:
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid gas price option", stringObj))
+		}
+		preprocessOptions.GasPriceOption = stringObj
+	}
+	if v, ok := req.Metadata["use_pending_nonce"]; ok {
+		boolObj, ok := v.(bool)
+		if !ok {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%v is not a valid use_pending_nonce bool", v))
+		}
+		preprocessOptions.UsePendingNonce = boolObj
+	}
 	if v, ok := req.Metadata["nonce"]; ok {
 		stringObj, ok := v.(string)
 		if !ok {
@@ -643,8 +1286,11 @@ func (s ConstructionService) ConstructionPreprocess(
 		}
 		preprocessOptions.Nonce = bigObj
 	}
+	if preprocessOptions.DynamicFee && (preprocessOptions.GasPrice != nil || len(preprocessOptions.GasPriceOption) > 0) {
+		return nil, WrapError(ErrInvalidInput, errDynamicFeeGasPrice)
+	}
 
-	marshaled, err := mapper.MarshalJSONMap(preprocessOptions)
+	marshaled, err := mapper.MarshalJSONMap(&preprocessOptions)
 	if err != nil {
 		return nil, WrapError(ErrInternalError, err)
 	}
@@ -654,6 +1300,46 @@ func (s ConstructionService) ConstructionPreprocess(
 	}, nil
 }
 
+// transferOptions returns the preprocess options of a matched transfer
+func transferOptions(matches []*parser.Match) (*options, *types.Error) {
+	fromOp, _ := matches[0].First()
+	fromAddress := fromOp.Account.Address
+	toOp, amount := matches[1].First()
+	toAddress := toOp.Account.Address
+
+	var fromCurrency *types.Currency
+	if fromOp.Amount != nil {
+		fromCurrency = fromOp.Amount.Currency
+	}
+
+	checkFrom, ok := ChecksumAddress(fromAddress)
+	if !ok {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", fromAddress))
+	}
+	checkTo, ok := ChecksumAddress(toAddress)
+	if !ok {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", toAddress))
+	}
+
+	transferOptions := &options{
+		From:     checkFrom,
+		To:       checkTo,
+		Value:    amount,
+		Currency: fromCurrency,
+	}
+
+	if fromOp.Type == mapper.OpErc721TransferSender {
+		contract, tokenID, err := parseErc721TransferOperations(fromOp, toOp)
+		if err != nil {
+			return nil, WrapError(ErrInvalidInput, err)
+		}
+		transferOptions.ContractAddress = contract
+		transferOptions.TokenID = tokenID
+	}
+
+	return transferOptions, nil
+}
+
 // ConstructionSubmit implements /construction/submit endpoint.
 //
 // Submit a pre-signed transaction to the node.
@@ -671,32 +1357,146 @@ func (s ConstructionService) ConstructionSubmit(
 	}
 
 	if s.pChainBackend.ShouldHandleRequest(req) {
-		return s.pChainBackend.ConstructionSubmit(ctx, req)
+		resp, terr := s.pChainBackend.ConstructionSubmit(ctx, req)
+		if terr == nil && s.submissionTracker != nil {
+			s.submissionTracker.TrackPChainTx(resp.TransactionIdentifier.Hash)
+		}
+		return resp, terr
 	}
 
 	if s.cChainAtomicTxBackend.ShouldHandleRequest(req) {
-		return s.cChainAtomicTxBackend.ConstructionSubmit(ctx, req)
+		resp, terr := s.cChainAtomicTxBackend.ConstructionSubmit(ctx, req)
+		if terr == nil && s.submissionTracker != nil {
+			s.submissionTracker.TrackAtomicTx(resp.TransactionIdentifier.Hash)
+		}
+		return resp, terr
 	}
 
-	var wrappedTx signedTransactionWrapper
-	if err := json.Unmarshal([]byte(req.SignedTransaction), &wrappedTx); err != nil {
+	wrappedTxs, err := decodeSignedTransactions(req.SignedTransaction)
+	if err != nil {
 		return nil, WrapError(ErrInvalidInput, err)
 	}
 
-	var signedTx ethtypes.Transaction
-	if err := signedTx.UnmarshalJSON(wrappedTx.SignedTransaction); err != nil {
-		return nil, WrapError(ErrInvalidInput, err)
+	signedTxs := make([]*ethtypes.Transaction, 0, len(wrappedTxs))
+	for _, wrappedTx := range wrappedTxs {
+		signedTx, err := wrappedTx.transaction()
+		if err != nil {
+			return nil, WrapError(ErrInvalidInput, err)
+		}
+		signedTxs = append(signedTxs, signedTx)
+	}
+
+	// The transactions of a batch are sent in order, so that their nonces
+	// are sequential in the mempool
+	hashes := make([]string, 0, len(signedTxs))
+	for i, signedTx := range signedTxs {
+		if err := s.client.SendTransaction(ctx, signedTx); err != nil {
+			if len(signedTxs) == 1 {
+				return nil, WrapError(ErrClientError, err)
+			}
+
+			// The transactions sent before the failing one can't be recalled,
+			// so their hashes are returned along with the failing index
+			terr := WrapError(ErrClientError, fmt.Errorf("transaction %d of the batch: %w", i, err))
+			terr.Details["failed_index"] = i
+			terr.Details["transaction_hashes"] = hashes
+			return nil, terr
+		}
+		if s.submissionTracker != nil {
+			s.submissionTracker.TrackEvmTx(signedTx)
+		}
+		hashes = append(hashes, signedTx.Hash().String())
 	}
 
-	if err := s.client.SendTransaction(ctx, &signedTx); err != nil {
-		return nil, WrapError(ErrClientError, err)
+	return batchTransactionIdentifier(hashes), nil
+}
+
+// matchTransfers splits operations into consecutive sender and receiver pairs
+// and matches each of them. More than one pair makes a batch of transfers.
+func (s ConstructionService) matchTransfers(operations []*types.Operation) ([][]*parser.Match, *types.Error) {
+	if len(operations) == 0 || len(operations)%2 != 0 {
+		return nil, WrapError(ErrInvalidInput, "invalid number of operations")
+	}
+	if len(operations)/2 > maxBatchTransfers {
+		return nil, WrapError(ErrInvalidInput, errBatchTooLarge)
 	}
 
-	return &types.TransactionIdentifierResponse{
-		TransactionIdentifier: &types.TransactionIdentifier{
-			Hash: signedTx.Hash().String(),
-		},
-	}, nil
+	transfers := make([][]*parser.Match, 0, len(operations)/2)
+	for i := 0; i < len(operations); i += 2 {
+		pair := operations[i : i+2]
+		operationDescriptions, err := s.CreateOperationDescription(pair)
+		if err != nil {
+			return nil, WrapError(ErrInvalidInput, err)
+		}
+
+		descriptions := &parser.Descriptions{
+			OperationDescriptions: operationDescriptions,
+			ErrUnmatched:          true,
+		}
+
+		matches, err := parser.MatchOperations(descriptions, pair)
+		if err != nil {
+			return nil, WrapError(ErrInvalidInput, "unclear intent")
+		}
+		transfers = append(transfers, matches)
+	}
+
+	return transfers, nil
+}
+
+// getNonce returns the nonce of the next transaction of from. Unless pending
+// is set, it is the nonce of the latest accepted state, which concurrent
+// constructions from one account all get. Otherwise the transactions waiting
+// in the tx pool are counted and, if the server reserves nonces, a nonce no
+// other construction got is returned.
+func (s ConstructionService) getNonce(ctx context.Context, from string, pending bool) (uint64, *types.Error) {
+	address := ethcommon.HexToAddress(from)
+
+	var nonce uint64
+	var err error
+	switch {
+	case !pending:
+		nonce, err = s.client.NonceAt(ctx, address, nil)
+	case s.nonceReserver != nil:
+		nonce, err = s.nonceReserver.ReserveNonce(ctx, address)
+	default:
+		nonce, err = s.client.PendingNonceAt(ctx, address)
+	}
+	if err != nil {
+		return 0, WrapError(ErrClientError, err)
+	}
+
+	return nonce, nil
+}
+
+// getGasLimit returns the gas limit given in the options, or estimates the
+// one of the transfer they describe
+func (s ConstructionService) getGasLimit(ctx context.Context, input *options) (uint64, *types.Error) {
+	if input.GasLimit != nil {
+		return input.GasLimit.Uint64(), nil
+	}
+
+	var gasLimit uint64
+	var err error
+	switch {
+	case len(input.AccessList) > 0:
+		// The access list changes the gas used, so the call is estimated
+		// along with it
+		gasLimit, err = s.client.EstimateGas(ctx, callMsg(input))
+	case len(input.Data) > 0:
+		gasLimit, err = s.getContractDeploymentGasLimit(ctx, input.From, input.Value, input.Data)
+	case input.TokenID != nil:
+		gasLimit, err = s.getErc721TransferGasLimit(ctx, input.To, input.From, input.ContractAddress, input.TokenID)
+	case input.Currency == nil || utils.Equal(input.Currency, mapper.AvaxCurrency):
+		gasLimit, err = s.getNativeTransferGasLimit(ctx, input.To, input.From, input.Value)
+	default:
+		gasLimit, err = s.getErc20TransferGasLimit(ctx, input.To, input.From, input.Value, input.Currency)
+	}
+	if err != nil {
+		return 0, WrapError(ErrClientError, err)
+	}
+
+	return s.config.BufferGasLimit(gasLimit), nil
 }
 
 func (s ConstructionService) CreateOperationDescription(
@@ -706,6 +1506,14 @@ func (s ConstructionService) CreateOperationDescription(
 		return nil, fmt.Errorf("invalid number of operations")
 	}
 
+	if operations[0].Type == mapper.OpErc721TransferSender {
+		return s.createErc721OperationDescription(), nil
+	}
+
+	if operations[0].Amount == nil || operations[1].Amount == nil {
+		return nil, fmt.Errorf("invalid amount on operation")
+	}
+
 	currency := operations[0].Amount.Currency
 
 	if currency == nil || operations[1].Amount.Currency == nil {
@@ -717,6 +1525,10 @@ func (s ConstructionService) CreateOperationDescription(
 	}
 
 	if utils.Equal(currency, mapper.AvaxCurrency) {
+		// A zero-value self-transfer cancels the transaction it replaces
+		if isZeroSelfTransfer(operations) {
+			return s.createZeroTransferDescription(currency), nil
+		}
 		return s.createOperationDescription(currency, mapper.OpCall), nil
 	}
 
@@ -761,6 +1573,45 @@ func (s ConstructionService) createOperationDescription(
 	}
 }
 
+// createZeroTransferDescription describes a zero-value AVAX transfer
+func (s ConstructionService) createZeroTransferDescription(
+	currency *types.Currency,
+) []*parser.OperationDescription {
+	descriptions := s.createOperationDescription(currency, mapper.OpCall)
+	for _, description := range descriptions {
+		description.Amount.Sign = parser.AnyAmountSign
+	}
+	return descriptions
+}
+
+// createErc721OperationDescription describes the transfer of a single
+// ERC-721 token, identified by the operations metadata
+func (s ConstructionService) createErc721OperationDescription() []*parser.OperationDescription {
+	return []*parser.OperationDescription{
+		// Send
+		{
+			Type: mapper.OpErc721TransferSender,
+			Account: &parser.AccountDescription{
+				Exists: true,
+			},
+			Amount: &parser.AmountDescription{
+				Exists: false,
+			},
+		},
+
+		// Receive
+		{
+			Type: mapper.OpErc721TransferReceive,
+			Account: &parser.AccountDescription{
+				Exists: true,
+			},
+			Amount: &parser.AmountDescription{
+				Exists: false,
+			},
+		},
+	}
+}
+
 func (s ConstructionService) getNativeTransferGasLimit(
 	ctx context.Context,
 	to string,
@@ -802,6 +1653,38 @@ func (s ConstructionService) getErc20TransferGasLimit(
 	})
 }
 
+func (s ConstructionService) getErc721TransferGasLimit(
+	ctx context.Context,
+	to string,
+	from string,
+	contract string,
+	tokenID *big.Int,
+) (uint64, error) {
+	if len(to) == 0 || len(contract) == 0 {
+		return erc721TransferGasLimit, nil
+	}
+
+	contractAddress := ethcommon.HexToAddress(contract)
+	return s.client.EstimateGas(ctx, interfaces.CallMsg{
+		From: ethcommon.HexToAddress(from),
+		To:   &contractAddress,
+		Data: generateErc721TransferData(from, to, tokenID),
+	})
+}
+
+func (s ConstructionService) getContractDeploymentGasLimit(
+	ctx context.Context,
+	from string,
+	value *big.Int,
+	data []byte,
+) (uint64, error) {
+	return s.client.EstimateGas(ctx, interfaces.CallMsg{
+		From:  ethcommon.HexToAddress(from),
+		Value: value,
+		Data:  data,
+	})
+}
+
 // Ref: https://goethereumbook.org/en/transfer-tokens/#forming-the-data-field
 func generateErc20TransferData(to string, value *big.Int) []byte {
 	toAddr := ethcommon.HexToAddress(to)
@@ -841,3 +1724,324 @@ func getMethodID(signature string) []byte {
 	hash.Write(bytes)
 	return hash.Sum(nil)[:4]
 }
+
+// parseErc721TransferOperations returns the contract address and the token id
+// carried in the metadata of matching ERC-721 sender and receiver operations
+func parseErc721TransferOperations(sender *types.Operation, receiver *types.Operation) (string, *big.Int, error) {
+	contract, ok := sender.Metadata[mapper.ContractAddressMetadata].(string)
+	if !ok {
+		return "", nil, fmt.Errorf("%s must be populated in operation metadata", mapper.ContractAddressMetadata)
+	}
+	checkContract, ok := ChecksumAddress(contract)
+	if !ok {
+		return "", nil, fmt.Errorf("%s is not a valid address", contract)
+	}
+
+	index, ok := sender.Metadata[mapper.IndexTransferredMetadata].(string)
+	if !ok || !has0xPrefix(index) {
+		return "", nil, fmt.Errorf("%s must be a hex encoded token id in operation metadata", mapper.IndexTransferredMetadata)
+	}
+	tokenID, ok := new(big.Int).SetString(index[2:], 16)
+	if !ok {
+		return "", nil, fmt.Errorf("%s is not a valid token id", index)
+	}
+
+	receiverContract, _ := receiver.Metadata[mapper.ContractAddressMetadata].(string)
+	receiverIndex, _ := receiver.Metadata[mapper.IndexTransferredMetadata].(string)
+	if !strings.EqualFold(contract, receiverContract) || !strings.EqualFold(index, receiverIndex) {
+		return "", nil, fmt.Errorf("token info doesn't match between the operations")
+	}
+
+	return checkContract, tokenID, nil
+}
+
+func generateErc721TransferData(from string, to string, tokenID *big.Int) []byte {
+	methodID := getMethodID(safeTransferFromFnSignature)
+
+	paddedFrom := ethcommon.LeftPadBytes(ethcommon.HexToAddress(from).Bytes(), padLength)
+	paddedTo := ethcommon.LeftPadBytes(ethcommon.HexToAddress(to).Bytes(), padLength)
+	paddedTokenID := ethcommon.LeftPadBytes(tokenID.Bytes(), padLength)
+
+	var data []byte
+	data = append(data, methodID...)
+	data = append(data, paddedFrom...)
+	data = append(data, paddedTo...)
+	data = append(data, paddedTokenID...)
+	return data
+}
+
+// isErc721TransferData reports whether data is a call to safeTransferFrom
+func isErc721TransferData(data []byte) bool {
+	return len(data) == safeTransferFromDataLength &&
+		bytes.Equal(data[:4], getMethodID(safeTransferFromFnSignature))
+}
+
+func parseErc721TransferData(data []byte) (*ethcommon.Address, *ethcommon.Address, *big.Int, error) {
+	if len(data) != safeTransferFromDataLength {
+		return nil, nil, nil, fmt.Errorf("incorrect length for data array")
+	}
+
+	methodBytes := data[:4]
+	if hexutil.Encode(methodBytes) != hexutil.Encode(getMethodID(safeTransferFromFnSignature)) {
+		return nil, nil, nil, fmt.Errorf("incorrect methodID signature")
+	}
+
+	from := ethcommon.BytesToAddress(data[4:36])
+	to := ethcommon.BytesToAddress(data[36:68])
+	tokenID := new(big.Int).SetBytes(data[68:])
+	return &from, &to, tokenID, nil
+}
+
+// callMsg returns the call made by the transaction input describes
+func callMsg(input *options) interfaces.CallMsg {
+	msg := interfaces.CallMsg{
+		From:       ethcommon.HexToAddress(input.From),
+		AccessList: input.AccessList,
+	}
+
+	to := ethcommon.HexToAddress(input.To)
+	switch {
+	case len(input.Data) > 0:
+		msg.Value = input.Value
+		msg.Data = input.Data
+	case input.TokenID != nil:
+		contract := ethcommon.HexToAddress(input.ContractAddress)
+		msg.To = &contract
+		msg.Data = generateErc721TransferData(input.From, input.To, input.TokenID)
+	case input.Currency == nil || utils.Equal(input.Currency, mapper.AvaxCurrency):
+		msg.To = &to
+		msg.Value = input.Value
+	default:
+		contractAddress, _ := input.Currency.Metadata[mapper.ContractAddressMetadata].(string)
+		contract := ethcommon.HexToAddress(contractAddress)
+		msg.To = &contract
+		msg.Data = generateErc20TransferData(input.To, input.Value)
+	}
+
+	return msg
+}
+
+// parseAccessList decodes an access list given in the preprocess metadata
+func parseAccessList(v interface{}) (ethtypes.AccessList, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var accessList ethtypes.AccessList
+	if err := json.Unmarshal(raw, &accessList); err != nil {
+		return nil, fmt.Errorf("%s is not a valid access list: %w", raw, err)
+	}
+	return accessList, nil
+}
+
+// isZeroSelfTransfer returns true if operations transfer no value from an
+// account to itself
+func isZeroSelfTransfer(operations []*types.Operation) bool {
+	for _, op := range operations {
+		if op.Account == nil || op.Amount == nil || op.Amount.Value != "0" {
+			return false
+		}
+	}
+	return strings.EqualFold(operations[0].Account.Address, operations[1].Account.Address)
+}
+
+// pooledGasPrice returns the gas price of the transaction of sender at nonce
+// in the tx pool, parsed from its summary "<to>: <value> wei + <gas> gas × <price> wei"
+func pooledGasPrice(content *client.TxPoolContent, sender ethcommon.Address, nonce uint64) (*big.Int, bool) {
+	for _, accountMap := range []client.TxAccountMap{content.Pending, content.Queued} {
+		for account, txs := range accountMap {
+			if !strings.EqualFold(account, sender.Hex()) {
+				continue
+			}
+			summary, ok := txs[strconv.FormatUint(nonce, 10)]
+			if !ok {
+				continue
+			}
+			i := strings.LastIndex(summary, "×")
+			if i < 0 {
+				return nil, false
+			}
+			fields := strings.Fields(summary[i+len("×"):])
+			if len(fields) != 2 {
+				return nil, false
+			}
+			return new(big.Int).SetString(fields[0], 10)
+		}
+	}
+	return nil, false
+}
+
+// isContractDeployment returns true if operations are the intent of a
+// contract deployment, a single CREATE operation of the deployer
+func isContractDeployment(operations []*types.Operation) bool {
+	return len(operations) == 1 && operations[0].Type == mapper.OpCreate
+}
+
+// parseDeploymentOperation returns the deployer, the value sent to the
+// constructor and the init code of a contract deployment. The operation
+// carries the contract bytecode and optional constructor args in its
+// metadata, and the value, if any, as a negative AVAX amount.
+func parseDeploymentOperation(operation *types.Operation) (string, *big.Int, []byte, error) {
+	if operation.Account == nil {
+		return "", nil, nil, errors.New("account is not provided")
+	}
+	from, ok := ChecksumAddress(operation.Account.Address)
+	if !ok {
+		return "", nil, nil, fmt.Errorf("%s is not a valid address", operation.Account.Address)
+	}
+
+	value := big.NewInt(0)
+	if operation.Amount != nil {
+		if !utils.Equal(operation.Amount.Currency, mapper.AvaxCurrency) {
+			return "", nil, nil, errInvalidDeploymentCurrency
+		}
+		amount, ok := new(big.Int).SetString(operation.Amount.Value, 10)
+		if !ok {
+			return "", nil, nil, fmt.Errorf("%s is not a valid amount", operation.Amount.Value)
+		}
+		if amount.Sign() > 0 {
+			return "", nil, nil, errPositiveDeploymentAmount
+		}
+		value.Neg(amount)
+	}
+
+	bytecode, ok := operation.Metadata[mapper.BytecodeMetadata].(string)
+	if !ok {
+		return "", nil, nil, errMissingBytecode
+	}
+	data, err := hexutil.Decode(bytecode)
+	if err != nil || len(data) == 0 {
+		return "", nil, nil, errMissingBytecode
+	}
+
+	if v, ok := operation.Metadata[mapper.ConstructorArgsMetadata]; ok {
+		constructorArgs, ok := v.(string)
+		if !ok {
+			return "", nil, nil, errInvalidConstructorArgs
+		}
+		args, err := hexutil.Decode(constructorArgs)
+		if err != nil {
+			return "", nil, nil, errInvalidConstructorArgs
+		}
+		data = append(data, args...)
+	}
+
+	return from, value, data, nil
+}
+
+// decodeUnsignedTransactions returns the transactions of an unsigned
+// transaction or of an unsigned batch
+func decodeUnsignedTransactions(unsigned string) ([]*transaction, error) {
+	var batch batchTransaction
+	if err := json.Unmarshal([]byte(unsigned), &batch); err != nil {
+		return nil, err
+	}
+	if len(batch.Transactions) > 0 {
+		return batch.Transactions, nil
+	}
+
+	var tx transaction
+	if err := json.Unmarshal([]byte(unsigned), &tx); err != nil {
+		return nil, err
+	}
+	return []*transaction{&tx}, nil
+}
+
+// decodeSignedTransactions returns the transactions of a signed transaction
+// or of a signed batch
+func decodeSignedTransactions(signed string) ([]*signedTransactionWrapper, error) {
+	var batch signedBatchTransaction
+	if err := json.Unmarshal([]byte(signed), &batch); err != nil {
+		return nil, err
+	}
+	if len(batch.SignedTransactions) > 0 {
+		return batch.SignedTransactions, nil
+	}
+
+	var wrappedTx signedTransactionWrapper
+	if err := json.Unmarshal([]byte(signed), &wrappedTx); err != nil {
+		return nil, err
+	}
+	return []*signedTransactionWrapper{&wrappedTx}, nil
+}
+
+// signTransaction adds signature to unsignedTx after checking that it is made
+// by the sender of unsignedTx, which is the account of its signing payload
+func signTransaction(unsignedTx *transaction, signature *types.Signature) (*signedTransactionWrapper, *types.Error) {
+	from := ethcommon.HexToAddress(unsignedTx.From)
+	if payload := signature.SigningPayload; payload != nil && payload.AccountIdentifier != nil &&
+		ethcommon.HexToAddress(payload.AccountIdentifier.Address) != from {
+		return nil, WrapError(ErrInvalidSignature,
+			fmt.Errorf("signing payload account %s is not the sender %s", payload.AccountIdentifier.Address, unsignedTx.From))
+	}
+
+	signer := ethtypes.LatestSignerForChainID(unsignedTx.ChainID)
+	signedTx, err := unsignedTx.ethTransaction().WithSignature(signer, signature.Bytes)
+	if err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+
+	sender, err := ethtypes.Sender(signer, signedTx)
+	if err != nil {
+		return nil, WrapError(ErrInvalidSignature, err)
+	}
+	if sender != from {
+		return nil, WrapError(ErrInvalidSignature, fmt.Errorf("signature is made by %s instead of %s", sender.Hex(), unsignedTx.From))
+	}
+
+	signedTxJSON, err := signedTx.MarshalJSON()
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+
+	return &signedTransactionWrapper{SignedTransaction: signedTxJSON, Currency: unsignedTx.Currency}, nil
+}
+
+// findSignature returns the signature of the payload of unsignedTx
+func findSignature(unsignedTx *transaction, signatures []*types.Signature) (*types.Signature, error) {
+	signer := ethtypes.LatestSignerForChainID(unsignedTx.ChainID)
+	payload := signer.Hash(unsignedTx.ethTransaction()).Bytes()
+	for _, signature := range signatures {
+		if signature.SigningPayload != nil && bytes.Equal(signature.SigningPayload.Bytes, payload) {
+			return signature, nil
+		}
+	}
+	return nil, errMissingSignature
+}
+
+// batchTransactionIdentifier identifies a transaction by the first of hashes.
+// The hashes of all the transactions of a batch are listed in the metadata.
+func batchTransactionIdentifier(hashes []string) *types.TransactionIdentifierResponse {
+	resp := &types.TransactionIdentifierResponse{
+		TransactionIdentifier: &types.TransactionIdentifier{
+			Hash: hashes[0],
+		},
+	}
+	if len(hashes) > 1 {
+		resp.Metadata = map[string]interface{}{
+			"transaction_hashes": hashes,
+		}
+	}
+	return resp
+}
+
+// offsetOperations shifts the indices of operations by offset
+func offsetOperations(operations []*types.Operation, offset int64) []*types.Operation {
+	for _, op := range operations {
+		op.OperationIdentifier.Index += offset
+		for _, related := range op.RelatedOperations {
+			related.Index += offset
+		}
+	}
+	return operations
+}
+
+func containsSigner(signers []*types.AccountIdentifier, address string) bool {
+	for _, signer := range signers {
+		if signer.Address == address {
+			return true
+		}
+	}
+	return false
+}
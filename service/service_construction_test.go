@@ -2,22 +2,30 @@ package service
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"math/big"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
 
+	ethtypes "github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/interfaces"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 
+	ethclient "github.com/ava-labs/avalanche-rosetta/client"
 	"github.com/ava-labs/avalanche-rosetta/mapper"
 	mocks "github.com/ava-labs/avalanche-rosetta/mocks/client"
 	backendMocks "github.com/ava-labs/avalanche-rosetta/mocks/service"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/nonce"
 )
 
 const (
@@ -46,6 +54,8 @@ func TestConstructionMetadata(t *testing.T) {
 			config: &Config{
 				Mode: ModeOffline,
 			},
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
 		}
 
 		resp, err := service.ConstructionMetadata(
@@ -180,6 +190,142 @@ func TestConstructionMetadata(t *testing.T) {
 			},
 		}, resp)
 	})
+
+	t.Run("fee multiplier and gas limit buffer", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := ConstructionService{
+			config:                &Config{Mode: ModeOnline, GasLimitBufferPercent: 20},
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		to := common.HexToAddress(defaultToAddress)
+		client.On("NonceAt", ctx, common.HexToAddress(defaultFromAddress), (*big.Int)(nil)).Return(uint64(0), nil).Once()
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(1_000_000_000), nil).Once()
+		client.On("EstimateGas", ctx, interfaces.CallMsg{
+			From:  common.HexToAddress(defaultFromAddress),
+			To:    &to,
+			Value: big.NewInt(1_000),
+		}).Return(uint64(21_001), nil).Once()
+
+		input := map[string]interface{}{
+			"from":                     defaultFromAddress,
+			"to":                       defaultToAddress,
+			"value":                    "0x3e8",
+			"suggested_fee_multiplier": 1.5,
+		}
+		resp, err := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{Options: input})
+		assert.Nil(t, err)
+		assert.Equal(t, &types.ConstructionMetadataResponse{
+			Metadata: forceMarshalMap(t, &metadata{
+				GasPrice: big.NewInt(1_500_000_000),
+				GasLimit: 25_201,
+				Nonce:    0,
+			}),
+			SuggestedFee: []*types.Amount{
+				{
+					Value:    "37801500000000",
+					Currency: mapper.AvaxCurrency,
+				},
+			},
+		}, resp)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("suggested fee above int64", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := ConstructionService{
+			config:                &Config{Mode: ModeOnline, GasLimitBufferPercent: 20},
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		client.On("NonceAt", ctx, common.HexToAddress(defaultFromAddress), (*big.Int)(nil)).Return(uint64(0), nil).Once()
+
+		// Explicit gas limits are not buffered
+		input := map[string]interface{}{
+			"from":      defaultFromAddress,
+			"to":        defaultToAddress,
+			"value":     "0x3e8",
+			"gas_price": "0x3635c9adc5dea00000",
+			"gas_limit": "0x5208",
+		}
+		resp, err := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{Options: input})
+		assert.Nil(t, err)
+		assert.Equal(t, []*types.Amount{
+			{
+				Value:    "21000000000000000000000000",
+				Currency: mapper.AvaxCurrency,
+			},
+		}, resp.SuggestedFee)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("fee above the max fee", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := ConstructionService{
+			config: &Config{
+				Mode:    ModeOnline,
+				ChainID: big.NewInt(43113),
+				MaxFees: map[string]*big.Int{
+					mapper.AvaxCurrency.Symbol: big.NewInt(21_000_000_000_000),
+					defaultContractAddress:     big.NewInt(1),
+				},
+			},
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		client.On("NonceAt", ctx, common.HexToAddress(defaultFromAddress), (*big.Int)(nil)).Return(uint64(0), nil).Twice()
+
+		input := map[string]interface{}{
+			"from":      defaultFromAddress,
+			"to":        defaultToAddress,
+			"value":     "0x3e8",
+			"gas_price": "0x3b9aca00",
+			"gas_limit": "0x5208",
+		}
+		resp, err := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{Options: input})
+		assert.Nil(t, err)
+		assert.Equal(t, "21000000000000", resp.SuggestedFee[0].Value)
+
+		input["gas_price"] = "0x3b9aca01"
+		resp, err = service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{Options: input})
+		assert.Nil(t, resp)
+		assert.Equal(t, ErrInvalidInput.Code, err.Code)
+		assert.Contains(t, err.Details["error"], errMaxFeeExceeded.Error())
+
+		// The max fee of a token is looked up by its contract address
+		currency := &types.Currency{
+			Symbol:   defaultSymbol,
+			Decimals: defaultDecimals,
+			Metadata: map[string]interface{}{mapper.ContractAddressMetadata: defaultContractAddress},
+		}
+		payloadsResp, err := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			Operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 0},
+					Type:                mapper.OpErc20Transfer,
+					Account:             &types.AccountIdentifier{Address: defaultFromAddress},
+					Amount:              &types.Amount{Value: "-1000", Currency: currency},
+				},
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 1},
+					Type:                mapper.OpErc20Transfer,
+					Account:             &types.AccountIdentifier{Address: defaultToAddress},
+					Amount:              &types.Amount{Value: "1000", Currency: currency},
+				},
+			},
+			Metadata: forceMarshalMap(t, &metadata{
+				GasPrice: big.NewInt(1),
+				GasLimit: 21_000,
+			}),
+		})
+		assert.Nil(t, payloadsResp)
+		assert.Equal(t, ErrInvalidInput.Code, err.Code)
+		assert.Contains(t, err.Details["error"], defaultContractAddress)
+		client.AssertExpectations(t)
+	})
 }
 
 func TestContructionHash(t *testing.T) {
@@ -305,6 +451,97 @@ func TestConstructionDerive(t *testing.T) {
 			resp.AccountIdentifier.Address,
 		)
 	})
+
+	t.Run("all address formats", func(t *testing.T) {
+		src := "03d0156cec2e01eff9c66e5dbc3c70f98214ec90a25eb43320ebcddc1a94b677f0"
+		b, _ := hex.DecodeString(src)
+		key, _ := ethcrypto.DecompressPubkey(b)
+
+		// Compressed and uncompressed keys derive the same addresses
+		for _, keyBytes := range [][]byte{b, ethcrypto.FromECDSAPub(key)} {
+			resp, err := service.ConstructionDerive(
+				context.Background(),
+				&types.ConstructionDeriveRequest{
+					NetworkIdentifier: &types.NetworkIdentifier{
+						Blockchain: "Avalanche",
+						Network:    mapper.FujiNetwork,
+					},
+					PublicKey: &types.PublicKey{
+						Bytes:     keyBytes,
+						CurveType: types.Secp256k1,
+					},
+					Metadata: map[string]interface{}{
+						mapper.MetaAddressFormat: mapper.AddressFormatAll,
+					},
+				},
+			)
+			assert.Nil(t, err)
+			assert.Equal(t, "0x156daFC6e9A1304fD5C9AB686acB4B3c802FE3f7", resp.AccountIdentifier.Address)
+			assert.Equal(t, map[string]interface{}{
+				mapper.MetaAddressFormat: mapper.AddressFormatAll,
+				mapper.MetaAddresses: map[string]string{
+					mapper.AddressFormatHex:        "0x156daFC6e9A1304fD5C9AB686acB4B3c802FE3f7",
+					mapper.CChainNetworkIdentifier: "C-fuji1r94egspx36c7zaf6h6d7jpsrz9smwxu3a73lgg",
+					mapper.PChainNetworkIdentifier: "P-fuji1r94egspx36c7zaf6h6d7jpsrz9smwxu3a73lgg",
+					mapper.XChainNetworkIdentifier: "X-fuji1r94egspx36c7zaf6h6d7jpsrz9smwxu3a73lgg",
+				},
+			}, resp.Metadata)
+		}
+	})
+
+	t.Run("unsupported curve type", func(t *testing.T) {
+		src := "03d0156cec2e01eff9c66e5dbc3c70f98214ec90a25eb43320ebcddc1a94b677f0"
+		b, _ := hex.DecodeString(src)
+
+		resp, err := service.ConstructionDerive(
+			context.Background(),
+			&types.ConstructionDeriveRequest{
+				PublicKey: &types.PublicKey{
+					Bytes:     b,
+					CurveType: types.Edwards25519,
+				},
+			},
+		)
+		assert.Nil(t, resp)
+		assert.Equal(t, ErrInvalidInput.Code, err.Code)
+		assert.Equal(
+			t,
+			"unsupported curve type edwards25519, only secp256k1 keys are supported",
+			err.Details["error"],
+		)
+	})
+
+	t.Run("unsupported curve type is rejected on the p-chain", func(t *testing.T) {
+		pChainBackend := &backendMocks.ConstructionBackend{}
+		pChainBackend.On("ShouldHandleRequest", mock.Anything).Return(true)
+		service := ConstructionService{
+			pChainBackend:         pChainBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+
+		resp, err := service.ConstructionDerive(
+			context.Background(),
+			&types.ConstructionDeriveRequest{
+				NetworkIdentifier: &types.NetworkIdentifier{
+					Blockchain:           BlockchainName,
+					Network:              "Fuji",
+					SubNetworkIdentifier: &types.SubNetworkIdentifier{Network: mapper.PChainNetworkIdentifier},
+				},
+				PublicKey: &types.PublicKey{
+					Bytes:     []byte{0x01},
+					CurveType: types.Secp256r1,
+				},
+			},
+		)
+		assert.Nil(t, resp)
+		assert.Equal(t, ErrInvalidInput.Code, err.Code)
+		assert.Equal(
+			t,
+			"unsupported curve type secp256r1, only secp256k1 keys are supported",
+			err.Details["error"],
+		)
+		pChainBackend.AssertNotCalled(t, "ConstructionDerive", mock.Anything, mock.Anything)
+	})
 }
 
 func forceMarshalMap(t *testing.T, i interface{}) map[string]interface{} {
@@ -878,6 +1115,224 @@ func TestPreprocessMetadata(t *testing.T) {
 			},
 		}, metadataResponse)
 	})
+
+	t.Run("basic erc721 flow", func(t *testing.T) {
+		from := "0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309"
+		to := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+		erc721Intent := `[{"operation_identifier":{"index":0},"type":"ERC721_SENDER","account":{"address":"0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309"},"metadata":{"contractAddress":"0x30e5449b6712Adf4156c8c474250F6eA4400eB82","indexTransferred":"0x000000000000000000000000000000000000000000000000000000000000002a"}},{"operation_identifier":{"index":1},"related_operations":[{"index":0}],"type":"ERC721_RECEIVE","account":{"address":"0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"},"metadata":{"contractAddress":"0x30e5449b6712Adf4156c8c474250F6eA4400eB82","indexTransferred":"0x000000000000000000000000000000000000000000000000000000000000002a"}}]`
+		transferData := common.Hex2Bytes("42842e0e000000000000000000000000e3a5b4d7f79d64088c8d4ef153a7dde2b2d4730900000000000000000000000057b414a0332b5cab885a451c2a28a07d1e9b8a8d000000000000000000000000000000000000000000000000000000000000002a")
+
+		service := ConstructionService{
+			config:                &Config{Mode: ModeOnline, ChainID: big.NewInt(43113)},
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		var ops []*types.Operation
+		assert.NoError(t, json.Unmarshal([]byte(erc721Intent), &ops))
+		preprocessResponse, err := service.ConstructionPreprocess(
+			ctx,
+			&types.ConstructionPreprocessRequest{
+				NetworkIdentifier: networkIdentifier,
+				Operations:        ops,
+			},
+		)
+		assert.Nil(t, err)
+		opt := &options{
+			From:            from,
+			To:              to,
+			ContractAddress: defaultContractAddress,
+			TokenID:         big.NewInt(42),
+		}
+		assert.Equal(t, &types.ConstructionPreprocessResponse{
+			Options: forceMarshalMap(t, opt),
+		}, preprocessResponse)
+
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(1000000000), nil).Once()
+		contractAddress := common.HexToAddress(defaultContractAddress)
+		client.On(
+			"EstimateGas",
+			ctx,
+			interfaces.CallMsg{
+				From: common.HexToAddress(from),
+				To:   &contractAddress,
+				Data: transferData,
+			},
+		).Return(uint64(60000), nil).Once()
+		client.On("NonceAt", ctx, common.HexToAddress(from), (*big.Int)(nil)).Return(uint64(3), nil).Once()
+
+		metadataResponse, err := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		assert.Nil(t, err)
+		metadata := &metadata{
+			GasPrice: big.NewInt(1000000000),
+			GasLimit: 60000,
+			Nonce:    3,
+		}
+		assert.Equal(t, forceMarshalMap(t, metadata), metadataResponse.Metadata)
+
+		payloadsResponse, err := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          metadataResponse.Metadata,
+		})
+		assert.Nil(t, err)
+		var unsignedTx transaction
+		assert.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTx))
+		assert.Equal(t, defaultContractAddress, unsignedTx.To)
+		assert.Zero(t, unsignedTx.Value.Sign())
+		assert.Equal(t, transferData, unsignedTx.Data)
+		assert.Nil(t, unsignedTx.Currency)
+
+		parseResponse, err := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Signed:            false,
+			Transaction:       payloadsResponse.UnsignedTransaction,
+		})
+		assert.Nil(t, err)
+		assert.Equal(
+			t,
+			forceMarshalMap(t, &types.ConstructionParseResponse{Operations: ops}),
+			forceMarshalMap(t, &types.ConstructionParseResponse{Operations: parseResponse.Operations}),
+		)
+	})
+
+	t.Run("erc721 token info doesn't match between the operations", func(t *testing.T) {
+		erc721Intent := `[{"operation_identifier":{"index":0},"type":"ERC721_SENDER","account":{"address":"0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309"},"metadata":{"contractAddress":"0x30e5449b6712Adf4156c8c474250F6eA4400eB82","indexTransferred":"0x2a"}},{"operation_identifier":{"index":1},"type":"ERC721_RECEIVE","account":{"address":"0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"},"metadata":{"contractAddress":"0x30e5449b6712Adf4156c8c474250F6eA4400eB82","indexTransferred":"0x2b"}}]`
+		var ops []*types.Operation
+		assert.NoError(t, json.Unmarshal([]byte(erc721Intent), &ops))
+		preprocessResponse, err := service.ConstructionPreprocess(
+			ctx,
+			&types.ConstructionPreprocessRequest{
+				NetworkIdentifier: networkIdentifier,
+				Operations:        ops,
+			},
+		)
+		assert.Nil(t, preprocessResponse)
+		assert.Equal(t, ErrInvalidInput.Code, err.Code)
+	})
+
+	t.Run("contract deployment flow", func(t *testing.T) {
+		from := "0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309"
+		deployIntent := `[{"operation_identifier":{"index":0},"type":"CREATE","account":{"address":"0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309"},"amount":{"value":"-1000","currency":{"symbol":"AVAX","decimals":18}},"metadata":{"bytecode":"0x6080604052","constructorArgs":"0x000000000000000000000000000000000000000000000000000000000000002a"}}]`
+		initCode := common.Hex2Bytes("6080604052000000000000000000000000000000000000000000000000000000000000002a")
+
+		service := ConstructionService{
+			config:                &Config{Mode: ModeOnline, ChainID: big.NewInt(43113)},
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		var ops []*types.Operation
+		assert.NoError(t, json.Unmarshal([]byte(deployIntent), &ops))
+		preprocessResponse, err := service.ConstructionPreprocess(
+			ctx,
+			&types.ConstructionPreprocessRequest{
+				NetworkIdentifier: networkIdentifier,
+				Operations:        ops,
+			},
+		)
+		assert.Nil(t, err)
+		opt := &options{
+			From:     from,
+			Value:    big.NewInt(1000),
+			Currency: mapper.AvaxCurrency,
+			Data:     initCode,
+		}
+		assert.Equal(t, &types.ConstructionPreprocessResponse{
+			Options: forceMarshalMap(t, opt),
+		}, preprocessResponse)
+
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(1000000000), nil).Once()
+		client.On(
+			"EstimateGas",
+			ctx,
+			interfaces.CallMsg{
+				From:  common.HexToAddress(from),
+				Value: big.NewInt(1000),
+				Data:  initCode,
+			},
+		).Return(uint64(120000), nil).Once()
+		client.On("NonceAt", ctx, common.HexToAddress(from), (*big.Int)(nil)).Return(uint64(7), nil).Once()
+
+		metadataResponse, err := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		assert.Nil(t, err)
+		metadata := &metadata{
+			GasPrice: big.NewInt(1000000000),
+			GasLimit: 120000,
+			Nonce:    7,
+		}
+		assert.Equal(t, forceMarshalMap(t, metadata), metadataResponse.Metadata)
+
+		payloadsResponse, err := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          metadataResponse.Metadata,
+		})
+		assert.Nil(t, err)
+		var unsignedTx transaction
+		assert.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTx))
+		assert.Empty(t, unsignedTx.To)
+		assert.Equal(t, big.NewInt(1000), unsignedTx.Value)
+		assert.Equal(t, initCode, unsignedTx.Data)
+		assert.Nil(t, unsignedTx.ethTransaction().To())
+
+		parseResponse, err := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Signed:            false,
+			Transaction:       payloadsResponse.UnsignedTransaction,
+		})
+		assert.Nil(t, err)
+		assert.Equal(
+			t,
+			forceMarshalMap(t, &types.ConstructionParseResponse{Operations: []*types.Operation{
+				{
+					OperationIdentifier: ops[0].OperationIdentifier,
+					Type:                ops[0].Type,
+					Account:             ops[0].Account,
+					Amount:              ops[0].Amount,
+				},
+			}}),
+			forceMarshalMap(t, &types.ConstructionParseResponse{Operations: parseResponse.Operations}),
+		)
+		// The contract address is derived from the deployer and its nonce
+		assert.Equal(t, "0x3A7e24ae7FF87E05950D0A36F3DF2Eb6a876360E", parseResponse.Metadata["contract_address"])
+	})
+
+	t.Run("contract deployment with positive amount", func(t *testing.T) {
+		deployIntent := `[{"operation_identifier":{"index":0},"type":"CREATE","account":{"address":"0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309"},"amount":{"value":"1000","currency":{"symbol":"AVAX","decimals":18}},"metadata":{"bytecode":"0x6080604052"}}]`
+		var ops []*types.Operation
+		assert.NoError(t, json.Unmarshal([]byte(deployIntent), &ops))
+		preprocessResponse, err := service.ConstructionPreprocess(
+			ctx,
+			&types.ConstructionPreprocessRequest{
+				NetworkIdentifier: networkIdentifier,
+				Operations:        ops,
+			},
+		)
+		assert.Nil(t, preprocessResponse)
+		assert.Equal(t, errPositiveDeploymentAmount.Error(), err.Details["error"])
+	})
+
+	t.Run("contract deployment without bytecode", func(t *testing.T) {
+		deployIntent := `[{"operation_identifier":{"index":0},"type":"CREATE","account":{"address":"0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309"}}]`
+		var ops []*types.Operation
+		assert.NoError(t, json.Unmarshal([]byte(deployIntent), &ops))
+		preprocessResponse, err := service.ConstructionPreprocess(
+			ctx,
+			&types.ConstructionPreprocessRequest{
+				NetworkIdentifier: networkIdentifier,
+				Operations:        ops,
+			},
+		)
+		assert.Nil(t, preprocessResponse)
+		assert.Equal(t, errMissingBytecode.Error(), err.Details["error"])
+	})
 }
 
 func TestBackendDelegations(t *testing.T) {
@@ -973,6 +1428,28 @@ func TestBackendDelegations(t *testing.T) {
 			assertBackendCalls(backends)
 		})
 
+		t.Run("Offline metadata request is served only by p-chain", func(t *testing.T) {
+			req := &types.ConstructionMetadataRequest{}
+
+			if idx != 0 {
+				resp, err := offlineService.ConstructionMetadata(context.Background(), req)
+				assert.Nil(t, resp)
+				assert.Equal(t, ErrUnavailableOffline, err)
+				return
+			}
+
+			expectedResp := &types.ConstructionMetadataResponse{
+				Metadata: map[string]interface{}{"key": "value"},
+			}
+
+			backends[idx].On("ConstructionMetadata", mock.Anything, req).Return(expectedResp, nil).Once()
+			resp, err := offlineService.ConstructionMetadata(context.Background(), req)
+
+			assert.Nil(t, err)
+			assert.Equal(t, expectedResp, resp)
+			assertBackendCalls(backends)
+		})
+
 		t.Run("Payloads request is delegated to "+backendName, func(t *testing.T) {
 			req := &types.ConstructionPayloadsRequest{}
 
@@ -1041,5 +1518,1023 @@ func TestBackendDelegations(t *testing.T) {
 			assert.Equal(t, expectedResp, resp)
 			assertBackendCalls(backends)
 		})
+
+		t.Run("Submitted tx is tracked by "+backendName, func(t *testing.T) {
+			req := &types.ConstructionSubmitRequest{SignedTransaction: "signedtxn"}
+			expectedResp := &types.TransactionIdentifierResponse{
+				TransactionIdentifier: &types.TransactionIdentifier{Hash: "txn hash"},
+			}
+
+			submissionTracker := &backendMocks.SubmissionTracker{}
+			if idx == 0 {
+				submissionTracker.On("TrackPChainTx", "txn hash").Once()
+			} else {
+				submissionTracker.On("TrackAtomicTx", "txn hash").Once()
+			}
+			trackingService := onlineService
+			trackingService.submissionTracker = submissionTracker
+
+			backends[idx].On("ConstructionSubmit", mock.Anything, req).Return(expectedResp, nil).Once()
+			resp, err := trackingService.ConstructionSubmit(context.Background(), req)
+
+			assert.Nil(t, err)
+			assert.Equal(t, expectedResp, resp)
+			assertBackendCalls(backends)
+			submissionTracker.AssertExpectations(t)
+		})
 	}
 }
+
+func TestBatchConstruction(t *testing.T) {
+	ctx := context.Background()
+	client := &mocks.Client{}
+	skippedBackend := &backendMocks.ConstructionBackend{}
+	skippedBackend.On("ShouldHandleRequest", mock.Anything).Return(false)
+	service := ConstructionService{
+		config:                &Config{Mode: ModeOnline, ChainID: big.NewInt(43113)},
+		client:                client,
+		pChainBackend:         skippedBackend,
+		cChainAtomicTxBackend: skippedBackend,
+	}
+	networkIdentifier := &types.NetworkIdentifier{
+		Network:    "Fuji",
+		Blockchain: "Avalanche",
+	}
+
+	key, err := ethcrypto.GenerateKey()
+	assert.NoError(t, err)
+	sender := ethcrypto.PubkeyToAddress(key.PublicKey)
+	receivers := []common.Address{
+		common.HexToAddress(defaultToAddress),
+		common.HexToAddress(defaultContractAddress),
+	}
+	amounts := []*big.Int{big.NewInt(1_000), big.NewInt(2_000)}
+
+	ops := []*types.Operation{}
+	for i, receiver := range receivers {
+		ops = append(ops,
+			&types.Operation{
+				OperationIdentifier: &types.OperationIdentifier{Index: int64(len(ops))},
+				Type:                mapper.OpCall,
+				Account:             &types.AccountIdentifier{Address: sender.Hex()},
+				Amount:              mapper.AvaxAmount(new(big.Int).Neg(amounts[i])),
+			},
+			&types.Operation{
+				OperationIdentifier: &types.OperationIdentifier{Index: int64(len(ops) + 1)},
+				Type:                mapper.OpCall,
+				Account:             &types.AccountIdentifier{Address: receiver.Hex()},
+				Amount:              mapper.AvaxAmount(amounts[i]),
+			},
+		)
+	}
+
+	t.Run("odd number of operations", func(t *testing.T) {
+		resp, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops[:3],
+		})
+		assert.Nil(t, resp)
+		assert.Equal(t, ErrInvalidInput.Code, terr.Code)
+	})
+
+	t.Run("batch flow", func(t *testing.T) {
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+		})
+		assert.Nil(t, terr)
+		var opt options
+		assert.NoError(t, mapper.UnmarshalJSONMap(preprocessResponse.Options, &opt))
+		assert.Equal(t, sender.Hex(), opt.From)
+		assert.Len(t, opt.Batch, 2)
+
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(1_000_000_000), nil).Once()
+		// The nonce is fetched once and incremented for the rest of the batch
+		client.On("NonceAt", ctx, sender, (*big.Int)(nil)).Return(uint64(5), nil).Once()
+		for i, receiver := range receivers {
+			to := receiver
+			client.On("EstimateGas", ctx, interfaces.CallMsg{
+				From:  sender,
+				To:    &to,
+				Value: amounts[i],
+			}).Return(uint64(21_000), nil).Once()
+		}
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		assert.Nil(t, terr)
+		assert.Equal(t, []*types.Amount{{
+			Value:    "42000000000000",
+			Currency: mapper.AvaxCurrency,
+		}}, metadataResponse.SuggestedFee)
+		var meta metadata
+		assert.NoError(t, mapper.UnmarshalJSONMap(metadataResponse.Metadata, &meta))
+		assert.Len(t, meta.Batch, 2)
+		assert.Equal(t, uint64(5), meta.Batch[0].Nonce)
+		assert.Equal(t, uint64(6), meta.Batch[1].Nonce)
+
+		payloadsResponse, terr := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          metadataResponse.Metadata,
+		})
+		assert.Nil(t, terr)
+		assert.Len(t, payloadsResponse.Payloads, 2)
+
+		parseResponse, terr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Transaction:       payloadsResponse.UnsignedTransaction,
+		})
+		assert.Nil(t, terr)
+		assert.Len(t, parseResponse.Operations, 4)
+		for i, op := range parseResponse.Operations {
+			assert.Equal(t, int64(i), op.OperationIdentifier.Index)
+		}
+
+		// Signatures are matched to the transactions by payload, whatever
+		// their order
+		signatures := []*types.Signature{}
+		for i := len(payloadsResponse.Payloads) - 1; i >= 0; i-- {
+			payload := payloadsResponse.Payloads[i]
+			sig, err := ethcrypto.Sign(payload.Bytes, key)
+			assert.NoError(t, err)
+			signatures = append(signatures, &types.Signature{
+				SigningPayload: payload,
+				SignatureType:  types.EcdsaRecovery,
+				Bytes:          sig,
+			})
+		}
+		combineResponse, terr := service.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+			NetworkIdentifier:   networkIdentifier,
+			UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+			Signatures:          signatures,
+		})
+		assert.Nil(t, terr)
+
+		signedParseResponse, terr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Signed:            true,
+			Transaction:       combineResponse.SignedTransaction,
+		})
+		assert.Nil(t, terr)
+		assert.Equal(t, parseResponse.Operations, signedParseResponse.Operations)
+		assert.Equal(t, []*types.AccountIdentifier{{Address: sender.Hex()}}, signedParseResponse.AccountIdentifierSigners)
+
+		hashResponse, terr := service.ConstructionHash(ctx, &types.ConstructionHashRequest{
+			NetworkIdentifier: networkIdentifier,
+			SignedTransaction: combineResponse.SignedTransaction,
+		})
+		assert.Nil(t, terr)
+		hashes, ok := hashResponse.Metadata["transaction_hashes"].([]string)
+		assert.True(t, ok)
+		assert.Len(t, hashes, 2)
+		assert.Equal(t, hashResponse.TransactionIdentifier.Hash, hashes[0])
+
+		client.On("SendTransaction", ctx, mock.Anything).Return(nil).Twice()
+		submitResponse, terr := service.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+			NetworkIdentifier: networkIdentifier,
+			SignedTransaction: combineResponse.SignedTransaction,
+		})
+		assert.Nil(t, terr)
+		assert.Equal(t, hashResponse, submitResponse)
+		client.AssertExpectations(t)
+
+		client.On("SendTransaction", ctx, mock.MatchedBy(func(tx *ethtypes.Transaction) bool {
+			return tx.Hash().String() == hashes[0]
+		})).Return(nil).Once()
+		client.On("SendTransaction", ctx, mock.MatchedBy(func(tx *ethtypes.Transaction) bool {
+			return tx.Hash().String() == hashes[1]
+		})).Return(errors.New("nonce too low")).Once()
+		submitResponse, terr = service.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+			NetworkIdentifier: networkIdentifier,
+			SignedTransaction: combineResponse.SignedTransaction,
+		})
+		assert.Nil(t, submitResponse)
+		assert.Equal(t, ErrClientError.Code, terr.Code)
+		assert.Equal(t, 1, terr.Details["failed_index"])
+		assert.Equal(t, []string{hashes[0]}, terr.Details["transaction_hashes"])
+		client.AssertExpectations(t)
+	})
+
+	t.Run("batch is capped", func(t *testing.T) {
+		transfer := &options{From: sender.Hex(), To: defaultToAddress, Value: big.NewInt(1)}
+		batch := make([]*options, maxBatchTransfers+1)
+		for i := range batch {
+			batch[i] = transfer
+		}
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(1_000_000_000), nil).Once()
+		resp, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           forceMarshalMap(t, &options{From: sender.Hex(), Batch: batch}),
+		})
+		assert.Nil(t, resp)
+		assert.Equal(t, errBatchTooLarge.Error(), terr.Details["error"])
+	})
+
+	t.Run("access lists are generated per transfer", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := ConstructionService{
+			config:                &Config{Mode: ModeOnline, ChainID: big.NewInt(43113)},
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"create_access_list":       true,
+				"max_priority_fee_per_gas": "1000000000",
+			},
+		})
+		assert.Nil(t, terr)
+
+		client.On("EstimateBaseFee", ctx).Return(big.NewInt(25_000_000_000), nil).Once()
+		client.On("NonceAt", ctx, sender, (*big.Int)(nil)).Return(uint64(5), nil).Once()
+		accessLists := make([]ethtypes.AccessList, len(receivers))
+		for i, receiver := range receivers {
+			to := receiver
+			accessLists[i] = ethtypes.AccessList{{Address: receiver, StorageKeys: []common.Hash{}}}
+			client.On("CreateAccessList", ctx, interfaces.CallMsg{
+				From:  sender,
+				To:    &to,
+				Value: amounts[i],
+			}).Return(&accessLists[i], uint64(23_000), nil).Once()
+			accessList := accessLists[i]
+			client.On("EstimateGas", ctx, mock.MatchedBy(func(msg interfaces.CallMsg) bool {
+				return *msg.To == to && reflect.DeepEqual(msg.AccessList, accessList)
+			})).Return(uint64(23_000), nil).Once()
+		}
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		assert.Nil(t, terr)
+		var meta metadata
+		assert.NoError(t, mapper.UnmarshalJSONMap(metadataResponse.Metadata, &meta))
+		for i, transfer := range meta.Batch {
+			assert.Equal(t, accessLists[i], transfer.AccessList)
+			assert.Equal(t, big.NewInt(51_000_000_000), transfer.MaxFeePerGas)
+			assert.Equal(t, big.NewInt(1_000_000_000), transfer.MaxPriorityFeePerGas)
+		}
+
+		payloadsResponse, terr := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          metadataResponse.Metadata,
+		})
+		assert.Nil(t, terr)
+		unsignedTxs, err := decodeUnsignedTransactions(payloadsResponse.UnsignedTransaction)
+		assert.NoError(t, err)
+		for i, unsignedTx := range unsignedTxs {
+			ethTx := unsignedTx.ethTransaction()
+			assert.Equal(t, uint8(ethtypes.DynamicFeeTxType), ethTx.Type())
+			assert.Equal(t, accessLists[i], ethTx.AccessList())
+		}
+		client.AssertExpectations(t)
+	})
+}
+
+func TestReplaceTransaction(t *testing.T) {
+	ctx := context.Background()
+	networkIdentifier := &types.NetworkIdentifier{
+		Network:    "Fuji",
+		Blockchain: "Avalanche",
+	}
+	skippedBackend := &backendMocks.ConstructionBackend{}
+	skippedBackend.On("ShouldHandleRequest", mock.Anything).Return(false)
+	config := &Config{Mode: ModeOnline, ChainID: big.NewInt(43113)}
+
+	key, err := ethcrypto.GenerateKey()
+	assert.NoError(t, err)
+	sender := ethcrypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress(defaultToAddress)
+	stuckTx, err := ethtypes.SignTx(
+		ethtypes.NewTransaction(4, to, big.NewInt(1_000), 21_000, big.NewInt(100_000_000_000), nil),
+		config.Signer(),
+		key,
+	)
+	assert.NoError(t, err)
+
+	preprocess := func(t *testing.T, service ConstructionService, ops []*types.Operation) map[string]interface{} {
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          map[string]interface{}{"replace_tx_hash": stuckTx.Hash().Hex()},
+		})
+		assert.Nil(t, terr)
+		assert.Equal(t, stuckTx.Hash().Hex(), preprocessResponse.Options["replace_tx_hash"])
+		return preprocessResponse.Options
+	}
+	transferOps := func(from common.Address, to common.Address, amount *big.Int) []*types.Operation {
+		return []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                mapper.OpCall,
+				Account:             &types.AccountIdentifier{Address: from.Hex()},
+				Amount:              mapper.AvaxAmount(new(big.Int).Neg(amount)),
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				Type:                mapper.OpCall,
+				Account:             &types.AccountIdentifier{Address: to.Hex()},
+				Amount:              mapper.AvaxAmount(amount),
+			},
+		}
+	}
+
+	t.Run("bumps the fee of a pending transaction", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := ConstructionService{
+			config:                config,
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		options := preprocess(t, service, transferOps(sender, to, big.NewInt(1_000)))
+
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(50_000_000_000), nil).Once()
+		client.On("TransactionByHash", ctx, stuckTx.Hash()).Return(stuckTx, true, nil).Once()
+		// The stuck transaction was already replaced once in the pool
+		client.On("TxPoolContent", ctx).Return(&ethclient.TxPoolContent{
+			Pending: ethclient.TxAccountMap{
+				strings.ToLower(sender.Hex()): ethclient.TxNonceMap{
+					"4": defaultToAddress + ": 1000 wei + 21000 gas × 110000000000 wei",
+				},
+			},
+		}, nil).Once()
+		client.On("EstimateGas", ctx, interfaces.CallMsg{
+			From:  sender,
+			To:    &to,
+			Value: big.NewInt(1_000),
+		}).Return(uint64(21_000), nil).Once()
+
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           options,
+		})
+		assert.Nil(t, terr)
+		assert.Equal(t, forceMarshalMap(t, &metadata{
+			Nonce:    4,
+			GasPrice: big.NewInt(121_000_000_000),
+			GasLimit: 21_000,
+		}), metadataResponse.Metadata)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("cancels a pending transaction", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := ConstructionService{
+			config:                &Config{Mode: ModeOnline, ChainID: big.NewInt(43113), ReplacementFeeBumpPercent: 25},
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		ops := transferOps(sender, sender, big.NewInt(0))
+		options := preprocess(t, service, ops)
+
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(50_000_000_000), nil).Once()
+		client.On("TransactionByHash", ctx, stuckTx.Hash()).Return(stuckTx, true, nil).Once()
+		client.On("TxPoolContent", ctx).Return(&ethclient.TxPoolContent{}, nil).Once()
+		client.On("EstimateGas", ctx, mock.MatchedBy(func(msg interfaces.CallMsg) bool {
+			return msg.From == sender && *msg.To == sender && msg.Value.Sign() == 0
+		})).Return(uint64(21_000), nil).Once()
+
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           options,
+		})
+		assert.Nil(t, terr)
+
+		payloadsResponse, terr := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          metadataResponse.Metadata,
+		})
+		assert.Nil(t, terr)
+		var unsignedTx transaction
+		assert.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTx))
+		assert.Equal(t, sender.Hex(), unsignedTx.To)
+		assert.Zero(t, unsignedTx.Value.Sign())
+		assert.Equal(t, uint64(4), unsignedTx.Nonce)
+		assert.Equal(t, big.NewInt(125_000_000_000), unsignedTx.GasPrice)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("transaction is no longer pending", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := ConstructionService{
+			config:                config,
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		options := preprocess(t, service, transferOps(sender, to, big.NewInt(1_000)))
+
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(50_000_000_000), nil).Once()
+		client.On("TransactionByHash", ctx, stuckTx.Hash()).Return(stuckTx, false, nil).Once()
+
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           options,
+		})
+		assert.Nil(t, metadataResponse)
+		assert.Equal(t, errReplacedTxNotPending.Error(), terr.Details["error"])
+	})
+
+	t.Run("transaction is sent by another account", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := ConstructionService{
+			config:                config,
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		options := preprocess(t, service, transferOps(common.HexToAddress(defaultFromAddress), to, big.NewInt(1_000)))
+
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(50_000_000_000), nil).Once()
+		client.On("TransactionByHash", ctx, stuckTx.Hash()).Return(stuckTx, true, nil).Once()
+
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           options,
+		})
+		assert.Nil(t, metadataResponse)
+		assert.Equal(t, errReplacedTxSender.Error(), terr.Details["error"])
+	})
+}
+
+func TestPendingNonce(t *testing.T) {
+	ctx := context.Background()
+	networkIdentifier := &types.NetworkIdentifier{
+		Network:    "Fuji",
+		Blockchain: "Avalanche",
+	}
+	skippedBackend := &backendMocks.ConstructionBackend{}
+	skippedBackend.On("ShouldHandleRequest", mock.Anything).Return(false)
+	from := common.HexToAddress(defaultFromAddress)
+	to := common.HexToAddress(defaultToAddress)
+
+	var ops []*types.Operation
+	assert.NoError(t, json.Unmarshal([]byte(`[{"operation_identifier":{"index":0},"type":"CALL","account":{"address":"0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309"},"amount":{"value":"-1000","currency":{"symbol":"AVAX","decimals":18}}},{"operation_identifier":{"index":1},"type":"CALL","account":{"address":"0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"},"amount":{"value":"1000","currency":{"symbol":"AVAX","decimals":18}}}]`), &ops))
+
+	construct := func(t *testing.T, service ConstructionService) uint64 {
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          map[string]interface{}{"use_pending_nonce": true},
+		})
+		assert.Nil(t, terr)
+		assert.Equal(t, true, preprocessResponse.Options["use_pending_nonce"])
+
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		assert.Nil(t, terr)
+		var meta metadata
+		assert.NoError(t, mapper.UnmarshalJSONMap(metadataResponse.Metadata, &meta))
+		return meta.Nonce
+	}
+	mockEstimates := func(client *mocks.Client) {
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(1_000_000_000), nil)
+		client.On("EstimateGas", ctx, interfaces.CallMsg{
+			From:  from,
+			To:    &to,
+			Value: big.NewInt(1_000),
+		}).Return(uint64(21_000), nil)
+	}
+
+	t.Run("pending nonce counts pooled transactions", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := ConstructionService{
+			config:                &Config{Mode: ModeOnline},
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		mockEstimates(client)
+		client.On("PendingNonceAt", ctx, from).Return(uint64(9), nil).Once()
+
+		assert.Equal(t, uint64(9), construct(t, service))
+		client.AssertNotCalled(t, "NonceAt", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("parallel constructions get distinct nonces", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := ConstructionService{
+			config:                &Config{Mode: ModeOnline},
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+			nonceReserver:         nonce.NewLedger(client),
+		}
+		mockEstimates(client)
+		client.On("PendingNonceAt", ctx, from).Return(uint64(9), nil).Twice()
+
+		assert.Equal(t, uint64(9), construct(t, service))
+		assert.Equal(t, uint64(10), construct(t, service))
+		client.AssertExpectations(t)
+	})
+}
+
+func TestAccessList(t *testing.T) {
+	ctx := context.Background()
+	networkIdentifier := &types.NetworkIdentifier{
+		Network:    "Fuji",
+		Blockchain: "Avalanche",
+	}
+	skippedBackend := &backendMocks.ConstructionBackend{}
+	skippedBackend.On("ShouldHandleRequest", mock.Anything).Return(false)
+	config := &Config{Mode: ModeOnline, ChainID: big.NewInt(43113)}
+
+	key, err := ethcrypto.GenerateKey()
+	assert.NoError(t, err)
+	sender := ethcrypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress(defaultToAddress)
+	accessList := ethtypes.AccessList{
+		{
+			Address:     to,
+			StorageKeys: []common.Hash{common.HexToHash("0x01")},
+		},
+	}
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                mapper.OpCall,
+			Account:             &types.AccountIdentifier{Address: sender.Hex()},
+			Amount:              mapper.AvaxAmount(big.NewInt(-1_000)),
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			Type:                mapper.OpCall,
+			Account:             &types.AccountIdentifier{Address: to.Hex()},
+			Amount:              mapper.AvaxAmount(big.NewInt(1_000)),
+		},
+	}
+	withAccessList := mock.MatchedBy(func(msg interfaces.CallMsg) bool {
+		return msg.From == sender && *msg.To == to && reflect.DeepEqual(msg.AccessList, accessList)
+	})
+
+	construct := func(t *testing.T, service ConstructionService, preprocessMetadata map[string]interface{}) string {
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          preprocessMetadata,
+		})
+		assert.Nil(t, terr)
+
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		assert.Nil(t, terr)
+
+		payloadsResponse, terr := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          metadataResponse.Metadata,
+		})
+		assert.Nil(t, terr)
+		var unsignedTx transaction
+		assert.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTx))
+		assert.Equal(t, accessList, unsignedTx.AccessList)
+
+		payload := payloadsResponse.Payloads[0]
+		signature, err := ethcrypto.Sign(payload.Bytes, key)
+		assert.NoError(t, err)
+		combineResponse, terr := service.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+			NetworkIdentifier:   networkIdentifier,
+			UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+			Signatures: []*types.Signature{
+				{
+					SigningPayload: payload,
+					PublicKey:      &types.PublicKey{Bytes: ethcrypto.CompressPubkey(&key.PublicKey), CurveType: types.Secp256k1},
+					SignatureType:  types.EcdsaRecovery,
+					Bytes:          signature,
+				},
+			},
+		})
+		assert.Nil(t, terr)
+		return combineResponse.SignedTransaction
+	}
+	assertSignedAccessList := func(t *testing.T, service ConstructionService, signed string) {
+		var wrappedTx signedTransactionWrapper
+		assert.NoError(t, json.Unmarshal([]byte(signed), &wrappedTx))
+		signedTx, err := wrappedTx.transaction()
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(ethtypes.AccessListTxType), signedTx.Type())
+		assert.Equal(t, accessList, signedTx.AccessList())
+
+		parseResponse, terr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Signed:            true,
+			Transaction:       signed,
+		})
+		assert.Nil(t, terr)
+		assert.Equal(t, sender.Hex(), parseResponse.AccountIdentifierSigners[0].Address)
+		parsedAccessList, err := json.Marshal(parseResponse.Metadata["access_list"])
+		assert.NoError(t, err)
+		expectedAccessList, err := json.Marshal(accessList)
+		assert.NoError(t, err)
+		assert.JSONEq(t, string(expectedAccessList), string(parsedAccessList))
+	}
+
+	t.Run("access list is generated by the node", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := ConstructionService{
+			config:                config,
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(25_000_000_000), nil).Once()
+		client.On("NonceAt", ctx, sender, (*big.Int)(nil)).Return(uint64(0), nil).Once()
+		client.On("CreateAccessList", ctx, interfaces.CallMsg{
+			From:  sender,
+			To:    &to,
+			Value: big.NewInt(1_000),
+		}).Return(&accessList, uint64(23_100), nil).Once()
+		client.On("EstimateGas", ctx, withAccessList).Return(uint64(23_100), nil).Once()
+
+		signed := construct(t, service, map[string]interface{}{"create_access_list": true})
+		assertSignedAccessList(t, service, signed)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("access list is given in the preprocess metadata", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := ConstructionService{
+			config:                config,
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(25_000_000_000), nil).Once()
+		client.On("NonceAt", ctx, sender, (*big.Int)(nil)).Return(uint64(0), nil).Once()
+		client.On("EstimateGas", ctx, withAccessList).Return(uint64(23_100), nil).Once()
+
+		var preprocessMetadata map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(`{"access_list":[{"address":"`+defaultToAddress+`","storageKeys":["0x0000000000000000000000000000000000000000000000000000000000000001"]}]}`), &preprocessMetadata))
+		signed := construct(t, service, preprocessMetadata)
+		assertSignedAccessList(t, service, signed)
+		client.AssertNotCalled(t, "CreateAccessList", mock.Anything, mock.Anything)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("invalid access list", func(t *testing.T) {
+		service := ConstructionService{
+			config:                config,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          map[string]interface{}{"access_list": "0x01"},
+		})
+		assert.Nil(t, preprocessResponse)
+		assert.Equal(t, ErrInvalidInput.Code, terr.Code)
+	})
+}
+
+func TestDynamicFees(t *testing.T) {
+	ctx := context.Background()
+	networkIdentifier := &types.NetworkIdentifier{
+		Network:    "Fuji",
+		Blockchain: "Avalanche",
+	}
+	skippedBackend := &backendMocks.ConstructionBackend{}
+	skippedBackend.On("ShouldHandleRequest", mock.Anything).Return(false)
+	config := &Config{Mode: ModeOnline, ChainID: big.NewInt(43113)}
+
+	key, err := ethcrypto.GenerateKey()
+	assert.NoError(t, err)
+	sender := ethcrypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress(defaultToAddress)
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                mapper.OpCall,
+			Account:             &types.AccountIdentifier{Address: sender.Hex()},
+			Amount:              mapper.AvaxAmount(big.NewInt(-1_000)),
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			Type:                mapper.OpCall,
+			Account:             &types.AccountIdentifier{Address: to.Hex()},
+			Amount:              mapper.AvaxAmount(big.NewInt(1_000)),
+		},
+	}
+	newService := func(client *mocks.Client) ConstructionService {
+		return ConstructionService{
+			config:                config,
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+		}
+	}
+	preprocess := func(t *testing.T, service ConstructionService, preprocessMetadata map[string]interface{}) map[string]interface{} {
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          preprocessMetadata,
+		})
+		assert.Nil(t, terr)
+		return preprocessResponse.Options
+	}
+
+	t.Run("suggested fees", func(t *testing.T) {
+		client := &mocks.Client{}
+		service := newService(client)
+		client.On("SuggestGasTipCap", ctx).Return(big.NewInt(1_000_000_000), nil).Once()
+		client.On("EstimateBaseFee", ctx).Return(big.NewInt(25_000_000_000), nil).Once()
+		client.On("NonceAt", ctx, sender, (*big.Int)(nil)).Return(uint64(3), nil).Once()
+		client.On("EstimateGas", ctx, interfaces.CallMsg{
+			From:  sender,
+			To:    &to,
+			Value: big.NewInt(1_000),
+		}).Return(uint64(21_000), nil).Once()
+
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocess(t, service, map[string]interface{}{"dynamic_fee": true}),
+		})
+		assert.Nil(t, terr)
+		var meta metadata
+		assert.NoError(t, mapper.UnmarshalJSONMap(metadataResponse.Metadata, &meta))
+		assert.Equal(t, big.NewInt(51_000_000_000), meta.MaxFeePerGas)
+		assert.Equal(t, big.NewInt(1_000_000_000), meta.MaxPriorityFeePerGas)
+		assert.Equal(t, meta.MaxFeePerGas, meta.GasPrice)
+		// The suggested fee is the most the transaction can pay
+		assert.Equal(t, "1071000000000000", metadataResponse.SuggestedFee[0].Value)
+
+		payloadsResponse, terr := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          metadataResponse.Metadata,
+		})
+		assert.Nil(t, terr)
+		payload := payloadsResponse.Payloads[0]
+		signature, err := ethcrypto.Sign(payload.Bytes, key)
+		assert.NoError(t, err)
+		combineResponse, terr := service.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+			NetworkIdentifier:   networkIdentifier,
+			UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+			Signatures: []*types.Signature{
+				{
+					SigningPayload: payload,
+					SignatureType:  types.EcdsaRecovery,
+					Bytes:          signature,
+				},
+			},
+		})
+		assert.Nil(t, terr)
+
+		var wrappedTx signedTransactionWrapper
+		assert.NoError(t, json.Unmarshal([]byte(combineResponse.SignedTransaction), &wrappedTx))
+		signedTx, err := wrappedTx.transaction()
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(ethtypes.DynamicFeeTxType), signedTx.Type())
+		assert.Equal(t, big.NewInt(51_000_000_000), signedTx.GasFeeCap())
+		assert.Equal(t, big.NewInt(1_000_000_000), signedTx.GasTipCap())
+
+		parseResponse, terr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Signed:            true,
+			Transaction:       combineResponse.SignedTransaction,
+		})
+		assert.Nil(t, terr)
+		assert.Equal(t, sender.Hex(), parseResponse.AccountIdentifierSigners[0].Address)
+		assert.Equal(t, "0xbdfd63e00", parseResponse.Metadata["max_fee_per_gas"])
+		assert.Equal(t, "0x3b9aca00", parseResponse.Metadata["max_priority_fee_per_gas"])
+		client.AssertExpectations(t)
+	})
+
+	t.Run("max fee below the max priority fee", func(t *testing.T) {
+		service := newService(&mocks.Client{})
+		resp, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options: preprocess(t, service, map[string]interface{}{
+				"max_fee_per_gas":          "1000000000",
+				"max_priority_fee_per_gas": "2000000000",
+			}),
+		})
+		assert.Nil(t, resp)
+		assert.Equal(t, ErrInvalidInput.Code, terr.Code)
+	})
+
+	t.Run("gas price along with dynamic fees", func(t *testing.T) {
+		service := newService(&mocks.Client{})
+		resp, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"gas_price":       "25000000000",
+				"max_fee_per_gas": "51000000000",
+			},
+		})
+		assert.Nil(t, resp)
+		assert.Equal(t, errDynamicFeeGasPrice.Error(), terr.Details["error"])
+	})
+}
+
+func TestGasPriceOptions(t *testing.T) {
+	ctx := context.Background()
+	networkIdentifier := &types.NetworkIdentifier{
+		Network:    "Fuji",
+		Blockchain: "Avalanche",
+	}
+	skippedBackend := &backendMocks.ConstructionBackend{}
+	skippedBackend.On("ShouldHandleRequest", mock.Anything).Return(false)
+	from := common.HexToAddress(defaultFromAddress)
+	to := common.HexToAddress(defaultToAddress)
+
+	var ops []*types.Operation
+	assert.NoError(t, json.Unmarshal([]byte(`[{"operation_identifier":{"index":0},"type":"CALL","account":{"address":"0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309"},"amount":{"value":"-1000","currency":{"symbol":"AVAX","decimals":18}}},{"operation_identifier":{"index":1},"type":"CALL","account":{"address":"0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"},"amount":{"value":"1000","currency":{"symbol":"AVAX","decimals":18}}}]`), &ops))
+	suggestions := &gasPrices{
+		Slow:   big.NewInt(25_000_000_000),
+		Normal: big.NewInt(27_000_000_000),
+		Fast:   big.NewInt(34_000_000_000),
+	}
+
+	metadataFor := func(t *testing.T, service ConstructionService, preprocessMetadata map[string]interface{}) (*types.ConstructionMetadataResponse, *types.Error) {
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          preprocessMetadata,
+		})
+		assert.Nil(t, terr)
+
+		return service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+	}
+	newService := func(client *mocks.Client, oracle GasPriceOracle) ConstructionService {
+		client.On("NonceAt", ctx, from, (*big.Int)(nil)).Return(uint64(0), nil)
+		client.On("EstimateGas", ctx, interfaces.CallMsg{
+			From:  from,
+			To:    &to,
+			Value: big.NewInt(1_000),
+		}).Return(uint64(21_000), nil)
+		return ConstructionService{
+			config:                &Config{Mode: ModeOnline},
+			client:                client,
+			pChainBackend:         skippedBackend,
+			cChainAtomicTxBackend: skippedBackend,
+			gasPriceOracle:        oracle,
+		}
+	}
+
+	t.Run("picked option", func(t *testing.T) {
+		client := &mocks.Client{}
+		oracle := &backendMocks.GasPriceOracle{}
+		oracle.On("SuggestGasPrices", ctx).Return(suggestions.Slow, suggestions.Normal, suggestions.Fast, nil).Once()
+		service := newService(client, oracle)
+
+		metadataResponse, terr := metadataFor(t, service, map[string]interface{}{"gas_price_option": "fast"})
+		assert.Nil(t, terr)
+		assert.Equal(t, forceMarshalMap(t, &metadata{
+			Nonce:     0,
+			GasPrice:  big.NewInt(34_000_000_000),
+			GasLimit:  21_000,
+			GasPrices: suggestions,
+		}), metadataResponse.Metadata)
+		assert.Equal(t, "714000000000000", metadataResponse.SuggestedFee[0].Value)
+		client.AssertNotCalled(t, "SuggestGasPrice", mock.Anything)
+		oracle.AssertExpectations(t)
+	})
+
+	t.Run("suggestions are returned without an option", func(t *testing.T) {
+		client := &mocks.Client{}
+		oracle := &backendMocks.GasPriceOracle{}
+		oracle.On("SuggestGasPrices", ctx).Return(suggestions.Slow, suggestions.Normal, suggestions.Fast, nil).Twice()
+		client.On("SuggestGasPrice", ctx).Return(big.NewInt(26_000_000_000), nil).Once()
+		service := newService(client, oracle)
+
+		metadataResponse, terr := metadataFor(t, service, nil)
+		assert.Nil(t, terr)
+		var meta metadata
+		assert.NoError(t, mapper.UnmarshalJSONMap(metadataResponse.Metadata, &meta))
+		assert.Equal(t, big.NewInt(26_000_000_000), meta.GasPrice)
+		assert.Equal(t, suggestions, meta.GasPrices)
+
+		metadataResponse, terr = metadataFor(t, service, map[string]interface{}{"gas_price": "30000000000"})
+		assert.Nil(t, terr)
+		assert.NoError(t, mapper.UnmarshalJSONMap(metadataResponse.Metadata, &meta))
+		assert.Equal(t, big.NewInt(30_000_000_000), meta.GasPrice)
+		assert.Equal(t, suggestions, meta.GasPrices)
+
+		oracle.AssertExpectations(t)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("option without oracle", func(t *testing.T) {
+		service := newService(&mocks.Client{}, nil)
+
+		metadataResponse, terr := metadataFor(t, service, map[string]interface{}{"gas_price_option": "slow"})
+		assert.Nil(t, metadataResponse)
+		assert.Equal(t, errNoGasPriceOracle.Error(), terr.Details["error"])
+	})
+
+	t.Run("invalid option", func(t *testing.T) {
+		service := newService(&mocks.Client{}, nil)
+
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          map[string]interface{}{"gas_price_option": "fastest"},
+		})
+		assert.Nil(t, preprocessResponse)
+		assert.Equal(t, ErrInvalidInput.Code, terr.Code)
+	})
+}
+
+func TestIsErc721TransferData(t *testing.T) {
+	data := generateErc721TransferData(
+		"0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309",
+		"0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d",
+		big.NewInt(42),
+	)
+	assert.True(t, isErc721TransferData(data))
+
+	// ERC-20 transferFrom calldata has the same length
+	otherData := append(getMethodID("transferFrom(address,address,uint256)"), data[4:]...)
+	assert.False(t, isErc721TransferData(otherData))
+	assert.False(t, isErc721TransferData(data[:len(data)-1]))
+}
+
+func TestCombineSignatureVerification(t *testing.T) {
+	ctx := context.Background()
+	skippedBackend := &backendMocks.ConstructionBackend{}
+	skippedBackend.On("ShouldHandleRequest", mock.Anything).Return(false)
+	config := &Config{Mode: ModeOffline, ChainID: big.NewInt(43113)}
+	service := ConstructionService{
+		config:                config,
+		pChainBackend:         skippedBackend,
+		cChainAtomicTxBackend: skippedBackend,
+	}
+
+	key, err := ethcrypto.GenerateKey()
+	assert.NoError(t, err)
+	otherKey, err := ethcrypto.GenerateKey()
+	assert.NoError(t, err)
+	sender := ethcrypto.PubkeyToAddress(key.PublicKey)
+
+	unsignedTx := &transaction{
+		From:     sender.Hex(),
+		To:       defaultToAddress,
+		Value:    big.NewInt(1_000),
+		Data:     []byte{},
+		Nonce:    0,
+		GasPrice: big.NewInt(25_000_000_000),
+		GasLimit: 21_000,
+		ChainID:  config.ChainID,
+		Currency: mapper.AvaxCurrency,
+	}
+	unsignedJSON, err := json.Marshal(unsignedTx)
+	assert.NoError(t, err)
+	payload := &types.SigningPayload{
+		AccountIdentifier: &types.AccountIdentifier{Address: sender.Hex()},
+		Bytes:             config.Signer().Hash(unsignedTx.ethTransaction()).Bytes(),
+		SignatureType:     types.EcdsaRecovery,
+	}
+	combine := func(signingPayload *types.SigningPayload, signingKey *ecdsa.PrivateKey) (*types.ConstructionCombineResponse, *types.Error) {
+		signature, err := ethcrypto.Sign(payload.Bytes, signingKey)
+		assert.NoError(t, err)
+		return service.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+			UnsignedTransaction: string(unsignedJSON),
+			Signatures: []*types.Signature{
+				{
+					SigningPayload: signingPayload,
+					SignatureType:  types.EcdsaRecovery,
+					Bytes:          signature,
+				},
+			},
+		})
+	}
+
+	t.Run("signature of the sender", func(t *testing.T) {
+		resp, terr := combine(payload, key)
+		assert.Nil(t, terr)
+		assert.NotEmpty(t, resp.SignedTransaction)
+	})
+
+	t.Run("signature of another account", func(t *testing.T) {
+		resp, terr := combine(payload, otherKey)
+		assert.Nil(t, resp)
+		assert.Equal(t, ErrInvalidSignature.Code, terr.Code)
+	})
+
+	t.Run("signing payload of another account", func(t *testing.T) {
+		resp, terr := combine(&types.SigningPayload{
+			AccountIdentifier: &types.AccountIdentifier{Address: defaultFromAddress},
+			Bytes:             payload.Bytes,
+			SignatureType:     types.EcdsaRecovery,
+		}, key)
+		assert.Nil(t, resp)
+		assert.Equal(t, ErrInvalidSignature.Code, terr.Code)
+	})
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+type EventsBackend interface {
+	ShouldHandleRequest(req interface{}) bool
+	EventsBlocks(ctx context.Context, req *types.EventsBlocksRequest) (*types.EventsBlocksResponse, *types.Error)
+}
+
+// EventsService implements the /events/* endpoints. A chain without a
+// backend does not serve block events.
+type EventsService struct {
+	config *Config
+
+	cChainBackend EventsBackend
+	pChainBackend EventsBackend
+}
+
+// NewEventsService returns a new events servicer
+func NewEventsService(config *Config, cChainBackend EventsBackend, pChainBackend EventsBackend) server.EventsAPIServicer {
+	return &EventsService{
+		config:        config,
+		cChainBackend: cChainBackend,
+		pChainBackend: pChainBackend,
+	}
+}
+
+// EventsBlocks implements the /events/blocks endpoint
+func (s *EventsService) EventsBlocks(
+	ctx context.Context,
+	req *types.EventsBlocksRequest,
+) (*types.EventsBlocksResponse, *types.Error) {
+	if s.config.IsOfflineMode() {
+		return nil, ErrUnavailableOffline
+	}
+
+	if s.pChainBackend == nil || s.cChainBackend == nil {
+		return nil, ErrNotImplemented
+	}
+
+	if s.pChainBackend.ShouldHandleRequest(req) {
+		return s.pChainBackend.EventsBlocks(ctx, req)
+	}
+
+	if s.cChainBackend.ShouldHandleRequest(req) {
+		return s.cChainBackend.EventsBlocks(ctx, req)
+	}
+
+	return nil, ErrNotImplemented
+}
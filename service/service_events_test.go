@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	mocks "github.com/ava-labs/avalanche-rosetta/mocks/service"
+)
+
+func TestEventsBlocks(t *testing.T) {
+	cBackendMock := &mocks.EventsBackend{}
+	pBackendMock := &mocks.EventsBackend{}
+	service := NewEventsService(&Config{Mode: ModeOnline}, cBackendMock, pBackendMock)
+
+	t.Run("p-chain request is delegated to p-chain backend", func(t *testing.T) {
+		req := &types.EventsBlocksRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{
+				Network: mapper.FujiNetwork,
+				SubNetworkIdentifier: &types.SubNetworkIdentifier{
+					Network: mapper.PChainNetworkIdentifier,
+				},
+			},
+		}
+
+		expectedResp := &types.EventsBlocksResponse{}
+		pBackendMock.On("ShouldHandleRequest", req).Return(true)
+		pBackendMock.On("EventsBlocks", mock.Anything, req).Return(expectedResp, nil)
+
+		resp, err := service.EventsBlocks(context.Background(), req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResp, resp)
+		pBackendMock.AssertExpectations(t)
+	})
+
+	t.Run("c-chain request is delegated to c-chain backend", func(t *testing.T) {
+		req := &types.EventsBlocksRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{
+				Network: mapper.FujiNetwork,
+			},
+		}
+
+		expectedResp := &types.EventsBlocksResponse{}
+		pBackendMock.On("ShouldHandleRequest", req).Return(false)
+		cBackendMock.On("ShouldHandleRequest", req).Return(true)
+		cBackendMock.On("EventsBlocks", mock.Anything, req).Return(expectedResp, nil)
+
+		resp, err := service.EventsBlocks(context.Background(), req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResp, resp)
+		cBackendMock.AssertExpectations(t)
+	})
+
+	t.Run("not implemented without backends", func(t *testing.T) {
+		resp, err := NewEventsService(&Config{Mode: ModeOnline}, nil, nil).EventsBlocks(context.Background(), &types.EventsBlocksRequest{})
+
+		assert.Equal(t, ErrNotImplemented, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("unavailable in offline mode", func(t *testing.T) {
+		offlineService := NewEventsService(&Config{Mode: ModeOffline}, cBackendMock, pBackendMock)
+
+		resp, err := offlineService.EventsBlocks(context.Background(), &types.EventsBlocksRequest{})
+
+		assert.Equal(t, ErrUnavailableOffline, err)
+		assert.Nil(t, resp)
+	})
+}
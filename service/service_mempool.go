@@ -10,17 +10,33 @@ import (
 	"github.com/ava-labs/avalanche-rosetta/mapper"
 )
 
+type MempoolBackend interface {
+	ShouldHandleRequest(req interface{}) bool
+	Mempool(ctx context.Context, req *types.NetworkRequest) (*types.MempoolResponse, *types.Error)
+	MempoolTransaction(ctx context.Context, req *types.MempoolTransactionRequest) (*types.MempoolTransactionResponse, *types.Error)
+}
+
 // MempoolService implements the /mempool/* endpoints
 type MempoolService struct {
 	config *Config
 	client client.Client
+
+	pChainBackend         MempoolBackend
+	cChainAtomicTxBackend MempoolBackend
 }
 
 // NewMempoolService returns a new mempool servicer
-func NewMempoolService(config *Config, client client.Client) server.MempoolAPIServicer {
+func NewMempoolService(
+	config *Config,
+	client client.Client,
+	pChainBackend MempoolBackend,
+	cChainAtomicTxBackend MempoolBackend,
+) server.MempoolAPIServicer {
 	return &MempoolService{
-		config: config,
-		client: client,
+		config:                config,
+		client:                client,
+		pChainBackend:         pChainBackend,
+		cChainAtomicTxBackend: cChainAtomicTxBackend,
 	}
 }
 
@@ -33,16 +49,29 @@ func (s MempoolService) Mempool(
 		return nil, ErrUnavailableOffline
 	}
 
+	if s.pChainBackend.ShouldHandleRequest(req) {
+		return s.pChainBackend.Mempool(ctx, req)
+	}
+
 	content, err := s.client.TxPoolContent(ctx)
 	if err != nil {
 		return nil, WrapError(ErrClientError, err)
 	}
 
+	// Atomic txs are not part of the evm txpool, they are tracked by the
+	// atomic tx backend from submission until they are decided
+	atomicResp, terr := s.cChainAtomicTxBackend.Mempool(ctx, req)
+	if terr != nil {
+		return nil, terr
+	}
+
+	transactionIdentifiers := append(
+		mapper.MempoolTransactionsIDs(content.Pending),
+		mapper.MempoolTransactionsIDs(content.Queued)...,
+	)
+
 	return &types.MempoolResponse{
-		TransactionIdentifiers: append(
-			mapper.MempoolTransactionsIDs(content.Pending),
-			mapper.MempoolTransactionsIDs(content.Queued)...,
-		),
+		TransactionIdentifiers: append(transactionIdentifiers, atomicResp.TransactionIdentifiers...),
 	}, nil
 }
 
@@ -51,5 +80,17 @@ func (s MempoolService) MempoolTransaction(
 	ctx context.Context,
 	req *types.MempoolTransactionRequest,
 ) (*types.MempoolTransactionResponse, *types.Error) {
+	if s.config.IsOfflineMode() {
+		return nil, ErrUnavailableOffline
+	}
+
+	if s.pChainBackend.ShouldHandleRequest(req) {
+		return s.pChainBackend.MempoolTransaction(ctx, req)
+	}
+
+	if s.cChainAtomicTxBackend.ShouldHandleRequest(req) {
+		return s.cChainAtomicTxBackend.MempoolTransaction(ctx, req)
+	}
+
 	return nil, ErrNotImplemented
 }
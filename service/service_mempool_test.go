@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	mocks "github.com/ava-labs/avalanche-rosetta/mocks/client"
+	serviceMocks "github.com/ava-labs/avalanche-rosetta/mocks/service"
+)
+
+func TestMempool(t *testing.T) {
+	clientMock := &mocks.Client{}
+	pBackendMock := &serviceMocks.MempoolBackend{}
+	cBackendMock := &serviceMocks.MempoolBackend{}
+	service := NewMempoolService(&Config{Mode: ModeOnline}, clientMock, pBackendMock, cBackendMock)
+
+	t.Run("p-chain request is delegated to p-chain backend", func(t *testing.T) {
+		req := &types.NetworkRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{
+				Network: mapper.FujiNetwork,
+				SubNetworkIdentifier: &types.SubNetworkIdentifier{
+					Network: mapper.PChainNetworkIdentifier,
+				},
+			},
+		}
+
+		expectedResp := &types.MempoolResponse{}
+		pBackendMock.On("ShouldHandleRequest", req).Return(true)
+		pBackendMock.On("Mempool", mock.Anything, req).Return(expectedResp, nil)
+
+		resp, err := service.Mempool(context.Background(), req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResp, resp)
+		pBackendMock.AssertExpectations(t)
+		clientMock.AssertNotCalled(t, "TxPoolContent", mock.Anything)
+	})
+
+	t.Run("c-chain request includes evm and atomic txs", func(t *testing.T) {
+		req := &types.NetworkRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{
+				Network: mapper.FujiNetwork,
+			},
+		}
+
+		pBackendMock.On("ShouldHandleRequest", req).Return(false)
+		clientMock.On("TxPoolContent", mock.Anything).Return(&client.TxPoolContent{
+			Pending: client.TxAccountMap{
+				"0x57B414a0332B5CF8EA3d7D3C4f0E6DfD0a0CAf69": client.TxNonceMap{
+					"1": "0x1:0x57B414a0332B5CF8EA3d7D3C4f0E6DfD0a0CAf69",
+				},
+			},
+		}, nil)
+		cBackendMock.On("Mempool", mock.Anything, req).Return(&types.MempoolResponse{
+			TransactionIdentifiers: []*types.TransactionIdentifier{{Hash: "atomic"}},
+		}, nil)
+
+		resp, err := service.Mempool(context.Background(), req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*types.TransactionIdentifier{{Hash: "0x1"}, {Hash: "atomic"}}, resp.TransactionIdentifiers)
+		clientMock.AssertExpectations(t)
+		cBackendMock.AssertExpectations(t)
+	})
+}
+
+func TestMempoolTransaction(t *testing.T) {
+	pBackendMock := &serviceMocks.MempoolBackend{}
+	cBackendMock := &serviceMocks.MempoolBackend{}
+	service := NewMempoolService(&Config{Mode: ModeOnline}, &mocks.Client{}, pBackendMock, cBackendMock)
+
+	t.Run("atomic tx is delegated to c-chain atomic tx backend", func(t *testing.T) {
+		req := &types.MempoolTransactionRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{
+				Network: mapper.FujiNetwork,
+			},
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: "atomic"},
+		}
+
+		expectedResp := &types.MempoolTransactionResponse{}
+		pBackendMock.On("ShouldHandleRequest", req).Return(false)
+		cBackendMock.On("ShouldHandleRequest", req).Return(true)
+		cBackendMock.On("MempoolTransaction", mock.Anything, req).Return(expectedResp, nil)
+
+		resp, err := service.MempoolTransaction(context.Background(), req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResp, resp)
+		cBackendMock.AssertExpectations(t)
+	})
+
+	t.Run("evm tx is not supported", func(t *testing.T) {
+		req := &types.MempoolTransactionRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{
+				Network: mapper.FujiNetwork,
+			},
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: "0x1"},
+		}
+
+		pBackendMock.On("ShouldHandleRequest", req).Return(false)
+		cBackendMock.On("ShouldHandleRequest", req).Return(false)
+
+		resp, err := service.MempoolTransaction(context.Background(), req)
+
+		assert.Equal(t, ErrNotImplemented, err)
+		assert.Nil(t, resp)
+	})
+}
@@ -25,10 +25,17 @@ type NetworkService struct {
 	pChainBackend NetworkBackend
 	client        client.Client
 	genesisBlock  *types.Block
+	callMethods   []string
 }
 
-// NewNetworkService returns a new network servicer
-func NewNetworkService(config *Config, client client.Client, pChainBackend NetworkBackend) server.NetworkAPIServicer {
+// NewNetworkService returns a new network servicer. backendCallMethods are the
+// call methods registered by the backends answering C-chain calls.
+func NewNetworkService(
+	config *Config,
+	client client.Client,
+	pChainBackend NetworkBackend,
+	backendCallMethods ...[]string,
+) server.NetworkAPIServicer {
 	genesisBlock := makeGenesisBlock(config.GenesisBlockHash)
 
 	return &NetworkService{
@@ -36,6 +43,7 @@ func NewNetworkService(config *Config, client client.Client, pChainBackend Netwo
 		client:        client,
 		genesisBlock:  genesisBlock,
 		pChainBackend: pChainBackend,
+		callMethods:   CallMethods(backendCallMethods...),
 	}
 }
 
@@ -137,7 +145,7 @@ func (s *NetworkService) NetworkOptions(
 		Allow: &types.Allow{
 			OperationStatuses:       mapper.OperationStatuses,
 			OperationTypes:          mapper.OperationTypes,
-			CallMethods:             mapper.CallMethods,
+			CallMethods:             s.callMethods,
 			Errors:                  Errors,
 			HistoricalBalanceLookup: true,
 		},
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+type SearchBackend interface {
+	ShouldHandleRequest(req interface{}) bool
+	SearchTransactions(ctx context.Context, req *types.SearchTransactionsRequest) (*types.SearchTransactionsResponse, *types.Error)
+}
+
+// SearchService implements the /search/* endpoints. Without a backend no
+// search is served.
+type SearchService struct {
+	config *Config
+
+	backend SearchBackend
+}
+
+// NewSearchService returns a new search servicer
+func NewSearchService(config *Config, backend SearchBackend) server.SearchAPIServicer {
+	return &SearchService{
+		config:  config,
+		backend: backend,
+	}
+}
+
+// SearchTransactions implements the /search/transactions endpoint
+func (s *SearchService) SearchTransactions(
+	ctx context.Context,
+	req *types.SearchTransactionsRequest,
+) (*types.SearchTransactionsResponse, *types.Error) {
+	if s.config.IsOfflineMode() {
+		return nil, ErrUnavailableOffline
+	}
+
+	if s.backend != nil && s.backend.ShouldHandleRequest(req) {
+		return s.backend.SearchTransactions(ctx, req)
+	}
+
+	return nil, ErrNotImplemented
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	mocks "github.com/ava-labs/avalanche-rosetta/mocks/service"
+)
+
+func TestSearchTransactions(t *testing.T) {
+	backendMock := &mocks.SearchBackend{}
+	service := NewSearchService(&Config{Mode: ModeOnline}, backendMock)
+
+	t.Run("request is delegated to backend", func(t *testing.T) {
+		req := &types.SearchTransactionsRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{
+				Network: mapper.FujiNetwork,
+			},
+			Address: types.String("0x57B414a0332B5CF8EA3d7D3C4f0E6DfD0a0CAf69"),
+		}
+
+		expectedResp := &types.SearchTransactionsResponse{}
+		backendMock.On("ShouldHandleRequest", req).Return(true)
+		backendMock.On("SearchTransactions", mock.Anything, req).Return(expectedResp, nil)
+
+		resp, err := service.SearchTransactions(context.Background(), req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResp, resp)
+		backendMock.AssertExpectations(t)
+	})
+
+	t.Run("not implemented without backend", func(t *testing.T) {
+		resp, err := NewSearchService(&Config{Mode: ModeOnline}, nil).SearchTransactions(context.Background(), &types.SearchTransactionsRequest{})
+
+		assert.Equal(t, ErrNotImplemented, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("unavailable in offline mode", func(t *testing.T) {
+		offlineService := NewSearchService(&Config{Mode: ModeOffline}, backendMock)
+
+		resp, err := offlineService.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{})
+
+		assert.Equal(t, ErrUnavailableOffline, err)
+		assert.Nil(t, resp)
+	})
+}
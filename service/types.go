@@ -6,7 +6,9 @@ import (
 	"strconv"
 
 	"github.com/ava-labs/avalanche-rosetta/mapper"
+	ethtypes "github.com/ava-labs/coreth/core/types"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -21,17 +23,61 @@ type options struct {
 	GasLimit               *big.Int        `json:"gas_limit,omitempty"`
 	Nonce                  *big.Int        `json:"nonce,omitempty"`
 	Currency               *types.Currency `json:"currency,omitempty"`
+
+	// ContractAddress and TokenID identify the token of an ERC-721 transfer
+	ContractAddress string   `json:"contract_address,omitempty"`
+	TokenID         *big.Int `json:"token_id,omitempty"`
+
+	// UsePendingNonce counts the transactions waiting in the tx pool in the
+	// nonce, and reserves it if the server keeps a nonce ledger
+	UsePendingNonce bool `json:"use_pending_nonce,omitempty"`
+
+	// GasPriceOption picks one of the gas prices suggested by the gas price
+	// oracle: slow, normal or fast
+	GasPriceOption string `json:"gas_price_option,omitempty"`
+
+	// AccessList makes an EIP-2930 transaction. If CreateAccessList is set,
+	// it is generated by the node.
+	AccessList       ethtypes.AccessList `json:"access_list,omitempty"`
+	CreateAccessList bool                `json:"create_access_list,omitempty"`
+
+	// DynamicFee makes an EIP-1559 transaction. The max fee per gas and the
+	// max priority fee per gas that are not set are suggested by the node.
+	DynamicFee           bool     `json:"dynamic_fee,omitempty"`
+	MaxFeePerGas         *big.Int `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas *big.Int `json:"max_priority_fee_per_gas,omitempty"`
+
+	// ReplaceTxHash is the hash of the pending transaction to replace
+	ReplaceTxHash string `json:"replace_tx_hash,omitempty"`
+
+	// Data is the init code of a contract deployment, constructor args included
+	Data []byte `json:"data,omitempty"`
+
+	// Batch lists the options of every transfer of a batch
+	Batch []*options `json:"batch,omitempty"`
 }
 
 type optionsWire struct {
-	From                   string          `json:"from"`
-	To                     string          `json:"to"`
-	Value                  string          `json:"value"`
-	SuggestedFeeMultiplier *float64        `json:"suggested_fee_multiplier,omitempty"`
-	GasPrice               string          `json:"gas_price,omitempty"`
-	GasLimit               string          `json:"gas_limit,omitempty"`
-	Nonce                  string          `json:"nonce,omitempty"`
-	Currency               *types.Currency `json:"currency,omitempty"`
+	From                   string              `json:"from"`
+	To                     string              `json:"to"`
+	Value                  string              `json:"value"`
+	SuggestedFeeMultiplier *float64            `json:"suggested_fee_multiplier,omitempty"`
+	GasPrice               string              `json:"gas_price,omitempty"`
+	GasLimit               string              `json:"gas_limit,omitempty"`
+	Nonce                  string              `json:"nonce,omitempty"`
+	Currency               *types.Currency     `json:"currency,omitempty"`
+	ContractAddress        string              `json:"contract_address,omitempty"`
+	TokenID                string              `json:"token_id,omitempty"`
+	Data                   string              `json:"data,omitempty"`
+	ReplaceTxHash          string              `json:"replace_tx_hash,omitempty"`
+	UsePendingNonce        bool                `json:"use_pending_nonce,omitempty"`
+	GasPriceOption         string              `json:"gas_price_option,omitempty"`
+	AccessList             ethtypes.AccessList `json:"access_list,omitempty"`
+	CreateAccessList       bool                `json:"create_access_list,omitempty"`
+	DynamicFee             bool                `json:"dynamic_fee,omitempty"`
+	MaxFeePerGas           string              `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas   string              `json:"max_priority_fee_per_gas,omitempty"`
+	Batch                  []*options          `json:"batch,omitempty"`
 }
 
 func (o *options) MarshalJSON() ([]byte, error) {
@@ -40,6 +86,14 @@ func (o *options) MarshalJSON() ([]byte, error) {
 		To:                     o.To,
 		SuggestedFeeMultiplier: o.SuggestedFeeMultiplier,
 		Currency:               o.Currency,
+		ContractAddress:        o.ContractAddress,
+		ReplaceTxHash:          o.ReplaceTxHash,
+		UsePendingNonce:        o.UsePendingNonce,
+		GasPriceOption:         o.GasPriceOption,
+		AccessList:             o.AccessList,
+		CreateAccessList:       o.CreateAccessList,
+		DynamicFee:             o.DynamicFee,
+		Batch:                  o.Batch,
 	}
 	if o.Value != nil {
 		ow.Value = hexutil.EncodeBig(o.Value)
@@ -47,12 +101,24 @@ func (o *options) MarshalJSON() ([]byte, error) {
 	if o.GasPrice != nil {
 		ow.GasPrice = hexutil.EncodeBig(o.GasPrice)
 	}
+	if o.MaxFeePerGas != nil {
+		ow.MaxFeePerGas = hexutil.EncodeBig(o.MaxFeePerGas)
+	}
+	if o.MaxPriorityFeePerGas != nil {
+		ow.MaxPriorityFeePerGas = hexutil.EncodeBig(o.MaxPriorityFeePerGas)
+	}
 	if o.GasLimit != nil {
 		ow.GasLimit = hexutil.EncodeBig(o.GasLimit)
 	}
 	if o.Nonce != nil {
 		ow.Nonce = hexutil.EncodeBig(o.Nonce)
 	}
+	if o.TokenID != nil {
+		ow.TokenID = hexutil.EncodeBig(o.TokenID)
+	}
+	if len(o.Data) > 0 {
+		ow.Data = hexutil.Encode(o.Data)
+	}
 
 	return json.Marshal(ow)
 }
@@ -66,6 +132,14 @@ func (o *options) UnmarshalJSON(data []byte) error {
 	o.To = ow.To
 	o.SuggestedFeeMultiplier = ow.SuggestedFeeMultiplier
 	o.Currency = ow.Currency
+	o.ContractAddress = ow.ContractAddress
+	o.ReplaceTxHash = ow.ReplaceTxHash
+	o.UsePendingNonce = ow.UsePendingNonce
+	o.GasPriceOption = ow.GasPriceOption
+	o.AccessList = ow.AccessList
+	o.CreateAccessList = ow.CreateAccessList
+	o.DynamicFee = ow.DynamicFee
+	o.Batch = ow.Batch
 
 	if len(ow.Value) > 0 {
 		value, err := hexutil.DecodeBig(ow.Value)
@@ -83,6 +157,22 @@ func (o *options) UnmarshalJSON(data []byte) error {
 		o.GasPrice = gasPrice
 	}
 
+	if len(ow.MaxFeePerGas) > 0 {
+		maxFeePerGas, err := hexutil.DecodeBig(ow.MaxFeePerGas)
+		if err != nil {
+			return err
+		}
+		o.MaxFeePerGas = maxFeePerGas
+	}
+
+	if len(ow.MaxPriorityFeePerGas) > 0 {
+		maxPriorityFeePerGas, err := hexutil.DecodeBig(ow.MaxPriorityFeePerGas)
+		if err != nil {
+			return err
+		}
+		o.MaxPriorityFeePerGas = maxPriorityFeePerGas
+	}
+
 	if len(ow.GasLimit) > 0 {
 		gasLimit, err := hexutil.DecodeBig(ow.GasLimit)
 		if err != nil {
@@ -99,6 +189,22 @@ func (o *options) UnmarshalJSON(data []byte) error {
 		o.Nonce = nonce
 	}
 
+	if len(ow.TokenID) > 0 {
+		tokenID, err := hexutil.DecodeBig(ow.TokenID)
+		if err != nil {
+			return err
+		}
+		o.TokenID = tokenID
+	}
+
+	if len(ow.Data) > 0 {
+		data, err := hexutil.Decode(ow.Data)
+		if err != nil {
+			return err
+		}
+		o.Data = data
+	}
+
 	return nil
 }
 
@@ -106,19 +212,45 @@ type metadata struct {
 	Nonce    uint64   `json:"nonce"`
 	GasPrice *big.Int `json:"gas_price"`
 	GasLimit uint64   `json:"gas_limit"`
+
+	// AccessList makes an EIP-2930 transaction
+	AccessList ethtypes.AccessList `json:"access_list,omitempty"`
+
+	// MaxFeePerGas and MaxPriorityFeePerGas make an EIP-1559 transaction,
+	// whose gas price is its max fee per gas
+	MaxFeePerGas         *big.Int `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas *big.Int `json:"max_priority_fee_per_gas,omitempty"`
+
+	// GasPrices are the gas prices suggested by the gas price oracle
+	GasPrices *gasPrices `json:"gas_prices,omitempty"`
+
+	// Batch lists the metadata of every transfer of a batch
+	Batch []*metadata `json:"batch,omitempty"`
 }
 
 type metadataWire struct {
-	Nonce    string `json:"nonce"`
-	GasPrice string `json:"gas_price"`
-	GasLimit string `json:"gas_limit"`
+	Nonce                string              `json:"nonce"`
+	GasPrice             string              `json:"gas_price"`
+	GasLimit             string              `json:"gas_limit"`
+	AccessList           ethtypes.AccessList `json:"access_list,omitempty"`
+	MaxFeePerGas         string              `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas string              `json:"max_priority_fee_per_gas,omitempty"`
+	GasPrices            *gasPrices          `json:"gas_prices,omitempty"`
+	Batch                []*metadata         `json:"batch,omitempty"`
 }
 
 func (m *metadata) MarshalJSON() ([]byte, error) {
 	mw := &metadataWire{
-		Nonce:    hexutil.Uint64(m.Nonce).String(),
-		GasPrice: hexutil.EncodeBig(m.GasPrice),
-		GasLimit: hexutil.Uint64(m.GasLimit).String(),
+		Nonce:      hexutil.Uint64(m.Nonce).String(),
+		GasPrice:   hexutil.EncodeBig(m.GasPrice),
+		GasLimit:   hexutil.Uint64(m.GasLimit).String(),
+		AccessList: m.AccessList,
+		GasPrices:  m.GasPrices,
+		Batch:      m.Batch,
+	}
+	if m.MaxFeePerGas != nil {
+		mw.MaxFeePerGas = hexutil.EncodeBig(m.MaxFeePerGas)
+		mw.MaxPriorityFeePerGas = hexutil.EncodeBig(m.MaxPriorityFeePerGas)
 	}
 
 	return json.Marshal(mw)
@@ -147,7 +279,90 @@ func (m *metadata) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	m.Nonce = nonce
+	m.AccessList = mw.AccessList
+	m.GasPrices = mw.GasPrices
+	m.Batch = mw.Batch
+
+	if len(mw.MaxFeePerGas) > 0 {
+		if m.MaxFeePerGas, err = hexutil.DecodeBig(mw.MaxFeePerGas); err != nil {
+			return err
+		}
+		if m.MaxPriorityFeePerGas, err = hexutil.DecodeBig(mw.MaxPriorityFeePerGas); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setDynamicFees makes m the metadata of an EIP-1559 transaction paying at
+// most maxFee per gas, tip included, if tip is set
+func (m *metadata) setDynamicFees(maxFee *big.Int, tip *big.Int) {
+	if tip == nil {
+		return
+	}
+	m.MaxFeePerGas = maxFee
+	m.MaxPriorityFeePerGas = tip
+}
 
+type gasPrices struct {
+	Slow   *big.Int `json:"slow"`
+	Normal *big.Int `json:"normal"`
+	Fast   *big.Int `json:"fast"`
+}
+
+type gasPricesWire struct {
+	Slow   string `json:"slow"`
+	Normal string `json:"normal"`
+	Fast   string `json:"fast"`
+}
+
+// option returns the gas price of the named option
+func (g *gasPrices) option(name string) (*big.Int, bool) {
+	switch name {
+	case gasPriceSlow:
+		return g.Slow, true
+	case gasPriceNormal:
+		return g.Normal, true
+	case gasPriceFast:
+		return g.Fast, true
+	default:
+		return nil, false
+	}
+}
+
+func (g *gasPrices) MarshalJSON() ([]byte, error) {
+	gw := &gasPricesWire{
+		Slow:   hexutil.EncodeBig(g.Slow),
+		Normal: hexutil.EncodeBig(g.Normal),
+		Fast:   hexutil.EncodeBig(g.Fast),
+	}
+
+	return json.Marshal(gw)
+}
+
+func (g *gasPrices) UnmarshalJSON(data []byte) error {
+	var gw gasPricesWire
+	if err := json.Unmarshal(data, &gw); err != nil {
+		return err
+	}
+
+	slow, err := hexutil.DecodeBig(gw.Slow)
+	if err != nil {
+		return err
+	}
+	normal, err := hexutil.DecodeBig(gw.Normal)
+	if err != nil {
+		return err
+	}
+	fast, err := hexutil.DecodeBig(gw.Fast)
+	if err != nil {
+		return err
+	}
+
+	g.Slow = slow
+	g.Normal = normal
+	g.Fast = fast
 	return nil
 }
 
@@ -156,21 +371,41 @@ type parseMetadata struct {
 	GasPrice *big.Int `json:"gas_price"`
 	GasLimit uint64   `json:"gas_limit"`
 	ChainID  *big.Int `json:"chain_id"`
+
+	// ContractAddress is the address of the contract deployed by the tx
+	ContractAddress string `json:"contract_address,omitempty"`
+
+	// AccessList is the EIP-2930 access list of the tx
+	AccessList ethtypes.AccessList `json:"access_list,omitempty"`
+
+	// MaxFeePerGas and MaxPriorityFeePerGas are the fees of an EIP-1559 tx
+	MaxFeePerGas         *big.Int `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas *big.Int `json:"max_priority_fee_per_gas,omitempty"`
 }
 
 type parseMetadataWire struct {
-	Nonce    string `json:"nonce"`
-	GasPrice string `json:"gas_price"`
-	GasLimit string `json:"gas_limit"`
-	ChainID  string `json:"chain_id"`
+	Nonce                string              `json:"nonce"`
+	GasPrice             string              `json:"gas_price"`
+	GasLimit             string              `json:"gas_limit"`
+	ChainID              string              `json:"chain_id"`
+	ContractAddress      string              `json:"contract_address,omitempty"`
+	AccessList           ethtypes.AccessList `json:"access_list,omitempty"`
+	MaxFeePerGas         string              `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas string              `json:"max_priority_fee_per_gas,omitempty"`
 }
 
 func (p *parseMetadata) MarshalJSON() ([]byte, error) {
 	pmw := &parseMetadataWire{
-		Nonce:    hexutil.Uint64(p.Nonce).String(),
-		GasPrice: hexutil.EncodeBig(p.GasPrice),
-		GasLimit: hexutil.Uint64(p.GasLimit).String(),
-		ChainID:  hexutil.EncodeBig(p.ChainID),
+		Nonce:           hexutil.Uint64(p.Nonce).String(),
+		GasPrice:        hexutil.EncodeBig(p.GasPrice),
+		GasLimit:        hexutil.Uint64(p.GasLimit).String(),
+		ChainID:         hexutil.EncodeBig(p.ChainID),
+		ContractAddress: p.ContractAddress,
+		AccessList:      p.AccessList,
+	}
+	if p.MaxFeePerGas != nil {
+		pmw.MaxFeePerGas = hexutil.EncodeBig(p.MaxFeePerGas)
+		pmw.MaxPriorityFeePerGas = hexutil.EncodeBig(p.MaxPriorityFeePerGas)
 	}
 
 	return json.Marshal(pmw)
@@ -186,31 +421,106 @@ type transaction struct {
 	GasLimit uint64          `json:"gas"`
 	ChainID  *big.Int        `json:"chain_id"`
 	Currency *types.Currency `json:"currency,omitempty"`
+
+	// AccessList makes an EIP-2930 transaction
+	AccessList ethtypes.AccessList `json:"access_list,omitempty"`
+
+	// MaxFeePerGas and MaxPriorityFeePerGas make an EIP-1559 transaction,
+	// whose gas price is its max fee per gas
+	MaxFeePerGas         *big.Int `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas *big.Int `json:"max_priority_fee_per_gas,omitempty"`
+}
+
+// batchTransaction is the unsigned transaction of a batch of transfers
+type batchTransaction struct {
+	Transactions []*transaction `json:"transactions"`
+}
+
+// ethTransaction returns the unsigned transaction to sign. A transaction
+// without recipient deploys a contract. Transactions with EIP-1559 fees are
+// dynamic fee (type 2) transactions, and the other ones with an access list
+// are access list (type 1) transactions.
+func (t *transaction) ethTransaction() *ethtypes.Transaction {
+	var to *common.Address
+	if len(t.To) > 0 {
+		address := common.HexToAddress(t.To)
+		to = &address
+	}
+	if t.MaxFeePerGas != nil {
+		return ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID:    t.ChainID,
+			Nonce:      t.Nonce,
+			GasTipCap:  t.MaxPriorityFeePerGas,
+			GasFeeCap:  t.MaxFeePerGas,
+			Gas:        t.GasLimit,
+			To:         to,
+			Value:      t.Value,
+			Data:       t.Data,
+			AccessList: t.AccessList,
+		})
+	}
+	if len(t.AccessList) > 0 {
+		return ethtypes.NewTx(&ethtypes.AccessListTx{
+			ChainID:    t.ChainID,
+			Nonce:      t.Nonce,
+			GasPrice:   t.GasPrice,
+			Gas:        t.GasLimit,
+			To:         to,
+			Value:      t.Value,
+			Data:       t.Data,
+			AccessList: t.AccessList,
+		})
+	}
+	if len(t.To) == 0 {
+		return ethtypes.NewContractCreation(
+			t.Nonce,
+			t.Value,
+			t.GasLimit,
+			t.GasPrice,
+			t.Data,
+		)
+	}
+	return ethtypes.NewTransaction(
+		t.Nonce,
+		common.HexToAddress(t.To),
+		t.Value,
+		t.GasLimit,
+		t.GasPrice,
+		t.Data,
+	)
 }
 
 type transactionWire struct {
-	From     string          `json:"from"`
-	To       string          `json:"to"`
-	Value    string          `json:"value"`
-	Data     string          `json:"data"`
-	Nonce    string          `json:"nonce"`
-	GasPrice string          `json:"gas_price"`
-	GasLimit string          `json:"gas"`
-	ChainID  string          `json:"chain_id"`
-	Currency *types.Currency `json:"currency,omitempty"`
+	From                 string              `json:"from"`
+	To                   string              `json:"to"`
+	Value                string              `json:"value"`
+	Data                 string              `json:"data"`
+	Nonce                string              `json:"nonce"`
+	GasPrice             string              `json:"gas_price"`
+	GasLimit             string              `json:"gas"`
+	ChainID              string              `json:"chain_id"`
+	Currency             *types.Currency     `json:"currency,omitempty"`
+	AccessList           ethtypes.AccessList `json:"access_list,omitempty"`
+	MaxFeePerGas         string              `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas string              `json:"max_priority_fee_per_gas,omitempty"`
 }
 
 func (t *transaction) MarshalJSON() ([]byte, error) {
 	tw := &transactionWire{
-		From:     t.From,
-		To:       t.To,
-		Value:    hexutil.EncodeBig(t.Value),
-		Data:     hexutil.Encode(t.Data),
-		Nonce:    hexutil.EncodeUint64(t.Nonce),
-		GasPrice: hexutil.EncodeBig(t.GasPrice),
-		GasLimit: hexutil.EncodeUint64(t.GasLimit),
-		ChainID:  hexutil.EncodeBig(t.ChainID),
-		Currency: t.Currency,
+		From:       t.From,
+		To:         t.To,
+		Value:      hexutil.EncodeBig(t.Value),
+		Data:       hexutil.Encode(t.Data),
+		Nonce:      hexutil.EncodeUint64(t.Nonce),
+		GasPrice:   hexutil.EncodeBig(t.GasPrice),
+		GasLimit:   hexutil.EncodeUint64(t.GasLimit),
+		ChainID:    hexutil.EncodeBig(t.ChainID),
+		Currency:   t.Currency,
+		AccessList: t.AccessList,
+	}
+	if t.MaxFeePerGas != nil {
+		tw.MaxFeePerGas = hexutil.EncodeBig(t.MaxFeePerGas)
+		tw.MaxPriorityFeePerGas = hexutil.EncodeBig(t.MaxPriorityFeePerGas)
 	}
 
 	return json.Marshal(tw)
@@ -262,6 +572,16 @@ func (t *transaction) UnmarshalJSON(data []byte) error {
 	t.ChainID = chainID
 	t.GasPrice = gasPrice
 	t.Currency = tw.Currency
+	t.AccessList = tw.AccessList
+
+	if len(tw.MaxFeePerGas) > 0 {
+		if t.MaxFeePerGas, err = hexutil.DecodeBig(tw.MaxFeePerGas); err != nil {
+			return err
+		}
+		if t.MaxPriorityFeePerGas, err = hexutil.DecodeBig(tw.MaxPriorityFeePerGas); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -333,3 +653,17 @@ func (t *signedTransactionWrapper) UnmarshalJSON(data []byte) error {
 	t.Currency = mapper.AvaxCurrency
 	return nil
 }
+
+// signedBatchTransaction is the signed transaction of a batch of transfers
+type signedBatchTransaction struct {
+	SignedTransactions []*signedTransactionWrapper `json:"signed_transactions"`
+}
+
+// transaction decodes the wrapped signed transaction
+func (t *signedTransactionWrapper) transaction() (*ethtypes.Transaction, error) {
+	var signedTx ethtypes.Transaction
+	if err := signedTx.UnmarshalJSON(t.SignedTransaction); err != nil {
+		return nil, err
+	}
+	return &signedTx, nil
+}